@@ -0,0 +1,85 @@
+package nhncloud
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
+)
+
+// blockStorageBackupV3Microversion is the first block storage microversion
+// that supports the metadata of backups.
+const blockStorageBackupV3Microversion = "3.43"
+
+func blockStorageBackupV3StateRefreshFunc(client *gophercloud.ServiceClient, backupID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		b, err := backups.Get(client, backupID).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return b, "deleted", nil
+			}
+
+			return nil, "", err
+		}
+
+		if b.Status == "error" || b.Status == "error_deleting" {
+			return b, b.Status, fmt.Errorf("The backup is in %s status: %s", b.Status, b.FailReason)
+		}
+
+		return b, b.Status, nil
+	}
+}
+
+func flattenBlockStorageBackupV3Metadata(metadata *map[string]string) map[string]string {
+	if metadata == nil {
+		return map[string]string{}
+	}
+
+	return *metadata
+}
+
+// blockStorageRetentionV3Item is a backup or a snapshot considered by
+// nhncloud_blockstorage_retention_policy_v3.
+type blockStorageRetentionV3Item struct {
+	ID        string
+	CreatedAt time.Time
+	Metadata  map[string]string
+}
+
+// blockStorageRetentionV3Matches reports whether metadata holds every pair
+// of match.
+func blockStorageRetentionV3Matches(metadata map[string]string, match map[string]string) bool {
+	for k, v := range match {
+		if metadata[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// blockStorageRetentionV3Expired returns the IDs of the items to prune, the
+// oldest first. An item is pruned when it is not one of the keep newest items
+// and it is older than maxAge. A zero keep or maxAge does not protect any
+// item.
+func blockStorageRetentionV3Expired(items []blockStorageRetentionV3Item, keep int, maxAge time.Duration, now time.Time) []string {
+	sorted := make([]blockStorageRetentionV3Item, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].CreatedAt.After(sorted[j].CreatedAt)
+	})
+
+	var expired []string
+	for i := len(sorted) - 1; i >= keep; i-- {
+		if maxAge > 0 && now.Sub(sorted[i].CreatedAt) <= maxAge {
+			continue
+		}
+		expired = append(expired, sorted[i].ID)
+	}
+
+	return expired
+}
@@ -0,0 +1,40 @@
+package nhncloud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitBlockStorageRetentionV3Matches(t *testing.T) {
+	metadata := map[string]string{"policy": "daily", "env": "prod"}
+
+	assert.True(t, blockStorageRetentionV3Matches(metadata, map[string]string{"policy": "daily"}))
+	assert.True(t, blockStorageRetentionV3Matches(metadata, map[string]string{"policy": "daily", "env": "prod"}))
+	assert.False(t, blockStorageRetentionV3Matches(metadata, map[string]string{"policy": "weekly"}))
+	assert.False(t, blockStorageRetentionV3Matches(metadata, map[string]string{"team": ""}))
+}
+
+func TestUnitBlockStorageRetentionV3Expired(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	items := []blockStorageRetentionV3Item{
+		{ID: "1h", CreatedAt: now.Add(-time.Hour)},
+		{ID: "10d", CreatedAt: now.Add(-240 * time.Hour)},
+		{ID: "3d", CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "1d", CreatedAt: now.Add(-24 * time.Hour)},
+	}
+
+	// keep alone prunes everything but the newest items.
+	assert.Equal(t, []string{"10d", "3d"}, blockStorageRetentionV3Expired(items, 2, 0, now))
+
+	// max_age alone prunes everything older than it.
+	assert.Equal(t, []string{"10d", "3d"}, blockStorageRetentionV3Expired(items, 0, 48*time.Hour, now))
+
+	// Both have to agree.
+	assert.Equal(t, []string{"10d"}, blockStorageRetentionV3Expired(items, 1, 100*time.Hour, now))
+	assert.Equal(t, []string{"10d"}, blockStorageRetentionV3Expired(items, 3, 2*time.Hour, now))
+
+	assert.Empty(t, blockStorageRetentionV3Expired(items, 4, 0, now))
+	assert.Empty(t, blockStorageRetentionV3Expired(nil, 0, time.Hour, now))
+}
@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/schedulerhints"
 	"github.com/gophercloud/utils/terraform/hashcode"
 )
@@ -82,3 +86,57 @@ func resourceBlockStorageSchedulerHints(schedulerHintsRaw map[string]interface{}
 
 	return schedulerHints
 }
+
+// blockStorageNhnEncryptionElem is the schema of an nhn_encryption block,
+// which encrypts a volume with a key of NHN Cloud Secure Key Manager (SKM).
+// The app key and the key ID only work as a pair, so neither may be blank.
+func blockStorageNhnEncryptionElem() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"skm_appkey": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"skm_key_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+		},
+	}
+}
+
+// blockStorageNhnEncryption is the nhn_encryption field of a volume create
+// request.
+type blockStorageNhnEncryption struct {
+	SkmAppkey string `json:"skm_appkey"`
+	SkmKeyID  string `json:"skm_key_id"`
+}
+
+func expandBlockStorageNhnEncryption(v []interface{}) *blockStorageNhnEncryption {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+
+	enc := v[0].(map[string]interface{})
+
+	return &blockStorageNhnEncryption{
+		SkmAppkey: enc["skm_appkey"].(string),
+		SkmKeyID:  enc["skm_key_id"].(string),
+	}
+}
+
+// blockStorageVolumeEncrypted reports whether a volume is encrypted. It works
+// with both the v2 and the v3 block storage clients.
+func blockStorageVolumeEncrypted(client *gophercloud.ServiceClient, volumeID string) (bool, error) {
+	var r struct {
+		Volume struct {
+			Encrypted bool `json:"encrypted"`
+		} `json:"volume"`
+	}
+
+	_, err := client.Get(client.ServiceURL("volumes", volumeID), &r, nil)
+
+	return r.Volume.Encrypted, err
+}
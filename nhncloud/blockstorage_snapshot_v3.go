@@ -1,8 +1,12 @@
 package nhncloud
 
 import (
+	"fmt"
 	"sort"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 )
 
@@ -29,3 +33,49 @@ func dataSourceBlockStorageV3MostRecentSnapshot(snapshots []snapshots.Snapshot)
 	sort.Sort(blockStorageV3SnapshotSort(sortedSnapshots))
 	return sortedSnapshots[len(sortedSnapshots)-1]
 }
+
+func blockStorageSnapshotV3StateRefreshFunc(client *gophercloud.ServiceClient, snapshotID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := snapshots.Get(client, snapshotID).Extract()
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return s, "deleted", nil
+			}
+
+			return nil, "", err
+		}
+
+		if s.Status == "error" || s.Status == "error_deleting" {
+			return s, s.Status, fmt.Errorf("The snapshot is in %s status. "+
+				"Please check with your cloud admin or check the Block Storage "+
+				"API logs to see why this error occurred.", s.Status)
+		}
+
+		return s, s.Status, nil
+	}
+}
+
+// blockStorageSnapshotV3SetMetadata creates or updates the given metadata
+// keys of a snapshot. Unlike snapshots.UpdateMetadata, it leaves the other
+// keys alone.
+func blockStorageSnapshotV3SetMetadata(client *gophercloud.ServiceClient, snapshotID string, metadata map[string]string) error {
+	b := map[string]interface{}{
+		"metadata": metadata,
+	}
+
+	_, err := client.Post(client.ServiceURL("snapshots", snapshotID, "metadata"), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+
+	return err
+}
+
+// blockStorageSnapshotV3DeleteMetadatum deletes a metadata key of a
+// snapshot. The upstream v3 snapshots package has no such request.
+func blockStorageSnapshotV3DeleteMetadatum(client *gophercloud.ServiceClient, snapshotID, key string) error {
+	_, err := client.Delete(client.ServiceURL("snapshots", snapshotID, "metadata", key), &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+
+	return err
+}
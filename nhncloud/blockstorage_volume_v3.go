@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
@@ -52,3 +53,71 @@ func blockStorageVolumeV3AttachmentHash(v interface{}) int {
 	}
 	return hashcode.String(buf.String())
 }
+
+// blockStorageVolumeV3CreateOptsExt adds the NHN Cloud nhn_encryption field,
+// which the upstream v3 volumes package does not know, to a create request.
+type blockStorageVolumeV3CreateOptsExt struct {
+	volumes.CreateOptsBuilder
+	NhnEncryption *blockStorageNhnEncryption
+}
+
+func (opts blockStorageVolumeV3CreateOptsExt) ToVolumeCreateMap() (map[string]interface{}, error) {
+	b, err := opts.CreateOptsBuilder.ToVolumeCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.NhnEncryption != nil {
+		b["volume"].(map[string]interface{})["nhn_encryption"] = opts.NhnEncryption
+	}
+
+	return b, nil
+}
+
+// blockStorageVolumeV3NhnEncryptionDiffSuppress keeps an encrypted volume
+// from being replaced when its nhn_encryption block is missing from the
+// state, as after an import. The API does not return the SKM key.
+func blockStorageVolumeV3NhnEncryptionDiffSuppress(_, _, _ string, d *schema.ResourceData) bool {
+	if d.Id() == "" || !d.Get("encrypted").(bool) {
+		return false
+	}
+
+	old, _ := d.GetChange("nhn_encryption")
+
+	return len(old.([]interface{})) == 0
+}
+
+// blockStorageVolumeV3NhnEncryptionElem is blockStorageNhnEncryptionElem with
+// blockStorageVolumeV3NhnEncryptionDiffSuppress on its fields, since the one
+// on the block only covers its item count.
+func blockStorageVolumeV3NhnEncryptionElem() *schema.Resource {
+	elem := blockStorageNhnEncryptionElem()
+	for _, s := range elem.Schema {
+		s.DiffSuppressFunc = blockStorageVolumeV3NhnEncryptionDiffSuppress
+	}
+
+	return elem
+}
+
+// blockStorageVolumeV3RevertMicroversion is the first block storage
+// microversion that can revert a volume to a snapshot.
+const blockStorageVolumeV3RevertMicroversion = "3.40"
+
+// blockStorageVolumeV3Revert reverts a volume in place to the data of one of
+// its snapshots. The upstream v3 volumes package has no such action.
+func blockStorageVolumeV3Revert(client *gophercloud.ServiceClient, volumeID, snapshotID string) error {
+	b := map[string]interface{}{
+		"revert": map[string]interface{}{
+			"snapshot_id": snapshotID,
+		},
+	}
+
+	revertClient := *client
+	revertClient.Microversion = blockStorageVolumeV3RevertMicroversion
+
+	_, err := revertClient.Post(revertClient.ServiceURL("volumes", volumeID, "action"), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+	})
+
+	return err
+}
@@ -28,6 +28,7 @@ const (
 	computeV2InstanceCreateServerWithTagsMicroversion        = "2.52"
 	computeV2TagsExtensionMicroversion                       = "2.26"
 	computeV2InstanceBlockDeviceVolumeTypeMicroversion       = "2.67"
+	computeV2InstanceRebuildUserDataMicroversion             = "2.57"
 )
 
 // InstanceNIC is a structured representation of a Gophercloud servers.Server
@@ -559,10 +560,10 @@ func computeV2InstanceReadTags(d *schema.ResourceData, tags []string) {
 	expandObjectReadTags(d, tags)
 }
 
-func computeV2InstanceUpdateTags(d *schema.ResourceData) []string {
-	return expandObjectUpdateTags(d)
+func computeV2InstanceUpdateTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectUpdateTags(d, defaultTags)
 }
 
-func computeV2InstanceTags(d *schema.ResourceData) []string {
-	return expandObjectTags(d)
+func computeV2InstanceTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectTags(d, defaultTags)
 }
@@ -0,0 +1,68 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+func dataSourceComputeInstanceConsoleOutputV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceComputeInstanceConsoleOutputV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"lines": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"output": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceComputeInstanceConsoleOutputV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+	opts := servers.ShowConsoleOutputOpts{
+		Length: d.Get("lines").(int),
+	}
+
+	output, err := servers.ShowConsoleOutput(computeClient, instanceID, opts).Extract()
+	if err != nil {
+		return diag.Errorf("Error retrieving the console output of nhncloud_compute_instance_v2 %s: %s", instanceID, err)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d bytes of console output of nhncloud_compute_instance_v2 %s", len(output), instanceID)
+
+	d.SetId(instanceID)
+	d.Set("output", output)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package nhncloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitComputeV2InstanceConsoleOutputDataSource_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConsoleOutputDataSourceConfig(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_compute_instance_console_output_v2.all", "id",
+						"nhncloud_compute_instance_v2.instance_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_compute_instance_console_output_v2.all", "output",
+						"[    0.000000] Linux version 5.15.0 (mock)\ninstance_1 login:\n"),
+					resource.TestCheckResourceAttr("data.nhncloud_compute_instance_console_output_v2.tail", "output",
+						"instance_1 login:\n"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitComputeV2InstanceConsoleOutputDataSourceConfig(imageID, flavorID, networkID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+
+data "nhncloud_compute_instance_console_output_v2" "all" {
+  instance_id = nhncloud_compute_instance_v2.instance_1.id
+}
+
+data "nhncloud_compute_instance_console_output_v2" "tail" {
+  instance_id = nhncloud_compute_instance_v2.instance_1.id
+  lines       = 1
+}
+`, imageID, flavorID, networkID)
+}
@@ -0,0 +1,133 @@
+package nhncloud
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+// dataSourceComputeInstancePasswordV2 retrieves the password generated by a
+// Windows image. The cloud only stores it encrypted with the public key of
+// the instance keypair, so it is decrypted locally with the private key.
+func dataSourceComputeInstancePasswordV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceComputeInstancePasswordV2Read,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"private_key": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceComputeInstancePasswordV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
+	}
+
+	privateKey, err := computeInstancePasswordV2ParsePrivateKey(d.Get("private_key").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+
+	// The password is only set once the instance has finished its first boot.
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"WAITING"},
+		Target:  []string{"AVAILABLE"},
+		Refresh: func() (interface{}, string, error) {
+			encrypted, err := servers.GetPassword(computeClient, instanceID).ExtractPassword(nil)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if encrypted == "" {
+				return encrypted, "WAITING", nil
+			}
+
+			return encrypted, "AVAILABLE", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutRead),
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for the password of nhncloud_compute_instance_v2 %s", instanceID)
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for the password of nhncloud_compute_instance_v2 %s: %s", instanceID, err)
+	}
+
+	password, err := servers.GetPassword(computeClient, instanceID).ExtractPassword(privateKey)
+	if err != nil {
+		return diag.Errorf("Error retrieving the password of nhncloud_compute_instance_v2 %s: %s", instanceID, err)
+	}
+
+	d.SetId(instanceID)
+	d.Set("password", password)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+// computeInstancePasswordV2ParsePrivateKey parses a PEM encoded RSA private
+// key, as returned by nhncloud_compute_keypair_v2 or ssh-keygen -m PEM.
+func computeInstancePasswordV2ParsePrivateKey(v string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(v))
+	if block == nil {
+		return nil, fmt.Errorf("Error parsing private_key: no PEM encoded key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing private_key: %s", err)
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Error parsing private_key: %T is not an RSA key", key)
+	}
+
+	return rsaKey, nil
+}
@@ -0,0 +1,125 @@
+package nhncloud
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitComputeV2InstancePasswordDataSource_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+	encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, &privateKey.PublicKey, []byte("Generated-Passw0rd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstancePasswordDataSourceConfig(imageID, flavorID, networkID, ""),
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["nhncloud_compute_instance_v2.instance_1"].Primary.ID
+					if !server.Update("servers", id, map[string]interface{}{
+						"_password": base64.StdEncoding.EncodeToString(encrypted),
+					}) {
+						return fmt.Errorf("Instance %s not found", id)
+					}
+
+					return nil
+				},
+			},
+			{
+				Config: providerConfig + testUnitComputeV2InstancePasswordDataSourceConfig(imageID, flavorID, networkID, string(privateKeyPEM)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_compute_instance_password_v2.password_1", "id",
+						"nhncloud_compute_instance_v2.instance_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_compute_instance_password_v2.password_1", "password", "Generated-Passw0rd"),
+				),
+			},
+			{
+				Config:      providerConfig + testUnitComputeV2InstancePasswordDataSourceConfig(imageID, flavorID, networkID, "not a key\n"),
+				ExpectError: regexp.MustCompile("no PEM encoded key found"),
+			},
+		},
+	})
+}
+
+func TestUnitComputeInstancePasswordV2ParsePrivateKey(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs1 := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	key, err := computeInstancePasswordV2ParsePrivateKey(string(pkcs1))
+	assert.NoError(t, err)
+	assert.True(t, privateKey.Equal(key))
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8 := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	key, err = computeInstancePasswordV2ParsePrivateKey(string(pkcs8))
+	assert.NoError(t, err)
+	assert.True(t, privateKey.Equal(key))
+
+	_, err = computeInstancePasswordV2ParsePrivateKey("not a key")
+	assert.Error(t, err)
+}
+
+func testUnitComputeV2InstancePasswordDataSourceConfig(imageID, flavorID, networkID, privateKey string) string {
+	config := fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, imageID, flavorID, networkID)
+
+	if privateKey == "" {
+		return config
+	}
+
+	return config + fmt.Sprintf(`
+data "nhncloud_compute_instance_password_v2" "password_1" {
+  instance_id = nhncloud_compute_instance_v2.instance_1.id
+  private_key = <<EOT
+%sEOT
+}
+`, privateKey)
+}
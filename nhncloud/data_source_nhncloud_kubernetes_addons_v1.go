@@ -0,0 +1,95 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceKubernetesAddonsV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKubernetesAddonsV1Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"kubernetes_version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"addons": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"versions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"default_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKubernetesAddonsV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	k8sVersion := d.Get("kubernetes_version").(string)
+	addonTypes, err := kubernetesAddonV1ListTypes(kubernetesClient, k8sVersion)
+	if err != nil {
+		return diag.Errorf("Error retrieving nhncloud_kubernetes_addons_v1 for %s: %s", k8sVersion, err)
+	}
+
+	addonType := d.Get("type").(string)
+	addons := make([]map[string]interface{}, 0, len(addonTypes))
+	for _, a := range addonTypes {
+		if addonType != "" && a.Type != addonType {
+			continue
+		}
+		addons = append(addons, map[string]interface{}{
+			"name":            a.Name,
+			"type":            a.Type,
+			"versions":        a.Versions,
+			"default_version": a.DefaultVersion,
+		})
+	}
+
+	log.Printf("[DEBUG] Retrieved %d nhncloud_kubernetes_addons_v1 for %s", len(addons), k8sVersion)
+
+	d.SetId(k8sVersion)
+	d.Set("addons", addons)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package nhncloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitKubernetesV1AddonsDataSource_basic(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitKubernetesV1AddonsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nhncloud_kubernetes_addons_v1.all", "addons.#", "3"),
+					resource.TestCheckResourceAttr("data.nhncloud_kubernetes_addons_v1.cni", "addons.#", "1"),
+					resource.TestCheckResourceAttr("data.nhncloud_kubernetes_addons_v1.cni", "addons.0.name", "calico"),
+					resource.TestCheckResourceAttr("data.nhncloud_kubernetes_addons_v1.cni", "addons.0.versions.#", "2"),
+					resource.TestCheckResourceAttr("data.nhncloud_kubernetes_addons_v1.cni", "addons.0.default_version", "v3.28.2-nks1"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitKubernetesV1AddonsDataSourceConfig = `
+data "nhncloud_kubernetes_addons_v1" "all" {
+  kubernetes_version = "v1.28.3"
+}
+
+data "nhncloud_kubernetes_addons_v1" "cni" {
+  kubernetes_version = "v1.28.3"
+  type               = "cni"
+}
+`
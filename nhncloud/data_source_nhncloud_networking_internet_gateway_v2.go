@@ -0,0 +1,107 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetworkingInternetGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkingInternetGatewayV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"external_network_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"routingtable_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"migrate_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNetworkingInternetGatewayV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	listOpts := internetGatewayListOpts{
+		ID:                d.Get("id").(string),
+		Name:              d.Get("name").(string),
+		ExternalNetworkID: d.Get("external_network_id").(string),
+		State:             d.Get("state").(string),
+	}
+
+	gateways, err := internetGatewayList(networkingClient, listOpts)
+	if err != nil {
+		return diag.Errorf("Error listing nhncloud_networking_internet_gateway_v2: %s", err)
+	}
+
+	if len(gateways) != 1 {
+		return diag.Errorf("There is not a single result: %d", len(gateways))
+	}
+
+	gateway := gateways[0]
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_internet_gateway_v2 %s: %#v", gateway.ID, gateway)
+
+	d.SetId(gateway.ID)
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", gateway.Name)
+	d.Set("external_network_id", gateway.ExternalNetworkID)
+	d.Set("routingtable_id", gateway.RoutingtableID)
+	d.Set("state", gateway.State)
+	d.Set("migrate_status", gateway.MigrateStatus)
+	d.Set("tenant_id", gateway.TenantID)
+	d.Set("create_time", gateway.CreateTime)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetworkingPeeringV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkingPeeringV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"local_vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"remote_vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"local_vpc_cidrv4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"remote_vpc_cidrv4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceNetworkingPeeringV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	listOpts := peeringListOpts{
+		ID:          d.Get("id").(string),
+		Name:        d.Get("name").(string),
+		State:       d.Get("state").(string),
+		LocalVPCID:  d.Get("local_vpc_id").(string),
+		RemoteVPCID: d.Get("remote_vpc_id").(string),
+	}
+
+	peerings, err := peeringList(networkingClient, listOpts)
+	if err != nil {
+		return diag.Errorf("Error listing nhncloud_networking_peering_v2: %s", err)
+	}
+
+	if len(peerings) != 1 {
+		return diag.Errorf("There is not a single result: %d", len(peerings))
+	}
+
+	p := peerings[0]
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_peering_v2 %s: %#v", p.ID, p)
+
+	d.SetId(p.ID)
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", p.Name)
+	d.Set("local_vpc_id", p.LocalVPCID)
+	d.Set("remote_vpc_id", p.RemoteVPCID)
+	d.Set("local_vpc_cidrv4", p.LocalVPC.CIDRv4)
+	d.Set("remote_vpc_cidrv4", p.RemoteVPC.CIDRv4)
+	d.Set("state", p.State)
+	d.Set("tenant_id", p.TenantID)
+	d.Set("create_time", p.CreateTime)
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/gophercloud/utils/terraform/hashcode"
+)
+
+func dataSourceNetworkingVPCSubnetsV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkingVPCSubnetsV2Read,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"routingtable_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"name_regex"},
+			},
+
+			"name_regex": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringIsValidRegExp,
+				ConflictsWith: []string{"name"},
+			},
+
+			"shared": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"vpcsubnets": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"vpc_cidrv4": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"cidr": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"gateway": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"available_ip_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+
+						"shared": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routingtable_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routingtable_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"routingtable_default_table": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+
+						"routingtable_gateway_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"create_time": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkingVPCSubnetsV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	listOpts := vpcSubnetListOpts{
+		VPCID:    d.Get("vpc_id").(string),
+		TenantID: d.Get("tenant_id").(string),
+		Name:     d.Get("name").(string),
+	}
+
+	allSubnets, err := vpcSubnetList(networkingClient, listOpts)
+	if err != nil {
+		return diag.Errorf("Unable to retrieve nhncloud_networking_vpcsubnets_v2: %s", err)
+	}
+
+	log.Printf("[DEBUG] Retrieved %d subnets in nhncloud_networking_vpcsubnets_v2", len(allSubnets))
+
+	var nameRegex *regexp.Regexp
+	if v, ok := d.GetOk("name_regex"); ok {
+		nameRegex = regexp.MustCompile(v.(string))
+	}
+
+	// The API may ignore some of the filters, so all of them are applied
+	// again here.
+	subnets := make([]vpcSubnetSummary, 0, len(allSubnets))
+	for _, subnet := range allSubnets {
+		if listOpts.VPCID != "" && subnet.VPCID != listOpts.VPCID {
+			continue
+		}
+		if listOpts.Name != "" && subnet.Name != listOpts.Name {
+			continue
+		}
+		if v, ok := d.GetOk("routingtable_id"); ok && subnet.Routingtable.ID != v.(string) {
+			continue
+		}
+		if v, ok := d.GetOkExists("shared"); ok && subnet.Shared != v.(bool) {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(subnet.Name) {
+			continue
+		}
+
+		subnets = append(subnets, subnet)
+	}
+
+	// Sort by name, then by ID, so that the indexes of the subnets stay the
+	// same from one run to the next.
+	sort.Slice(subnets, func(i, j int) bool {
+		if subnets[i].Name != subnets[j].Name {
+			return subnets[i].Name < subnets[j].Name
+		}
+		return subnets[i].ID < subnets[j].ID
+	})
+
+	ids := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		ids[i] = subnet.ID
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(strings.Join(ids, ","))))
+	d.Set("region", GetRegion(d, config))
+	d.Set("ids", ids)
+
+	if err := d.Set("vpcsubnets", flattenNetworkingVPCSubnetsV2(subnets)); err != nil {
+		return diag.Errorf("Unable to set vpcsubnets for nhncloud_networking_vpcsubnets_v2: %s", err)
+	}
+
+	return nil
+}
+
+func flattenNetworkingVPCSubnetsV2(subnets []vpcSubnetSummary) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(subnets))
+	for i, subnet := range subnets {
+		result[i] = map[string]interface{}{
+			"id":                         subnet.ID,
+			"name":                       subnet.Name,
+			"vpc_id":                     subnet.VPCID,
+			"vpc_name":                   subnet.VPC.Name,
+			"vpc_cidrv4":                 subnet.VPC.CIDRv4,
+			"cidr":                       subnet.CIDR,
+			"gateway":                    subnet.Gateway,
+			"available_ip_count":         subnet.AvailableIPCount,
+			"shared":                     subnet.Shared,
+			"state":                      subnet.State,
+			"routingtable_id":            subnet.Routingtable.ID,
+			"routingtable_name":          subnet.Routingtable.Name,
+			"routingtable_default_table": subnet.Routingtable.DefaultTable,
+			"routingtable_gateway_id":    subnet.Routingtable.GatewayID,
+			"tenant_id":                  subnet.TenantID,
+			"create_time":                subnet.CreateTime,
+		}
+	}
+
+	return result
+}
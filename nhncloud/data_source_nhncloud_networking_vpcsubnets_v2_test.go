@@ -0,0 +1,90 @@
+package nhncloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2VPCSubnetsDataSource_basic(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCSubnetsDataSourceResources,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCSubnetsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.#", "3"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.0.name", "subnet-a"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.1.name", "subnet-b"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.2.name", "web"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.0.cidr", "10.40.2.0/24"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.0.available_ip_count", "251"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.all", "vpcsubnets.0.gateway", "10.40.2.1"),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_networking_vpcsubnets_v2.all", "ids.1",
+						"nhncloud_networking_vpcsubnet_v2.subnet_b", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.regex", "vpcsubnets.#", "2"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.routingtable", "vpcsubnets.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_networking_vpcsubnets_v2.routingtable", "vpcsubnets.0.routingtable_id",
+						"nhncloud_networking_routingtable_v2.routingtable_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_vpcsubnets_v2.routingtable", "vpcsubnets.0.name", "web"),
+				),
+			},
+		},
+	})
+}
+
+const testUnitNetworkingV2VPCSubnetsDataSourceResources = `
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.40.0.0/16"
+}
+
+resource "nhncloud_networking_routingtable_v2" "routingtable_1" {
+  name   = "routingtable_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_b" {
+  name   = "subnet-b"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.40.1.0/24"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_a" {
+  name   = "subnet-a"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.40.2.0/24"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "web" {
+  name            = "web"
+  vpc_id          = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr            = "10.40.3.0/24"
+  routingtable_id = nhncloud_networking_routingtable_v2.routingtable_1.id
+}
+`
+
+var testUnitNetworkingV2VPCSubnetsDataSourceConfig = fmt.Sprintf(`
+%s
+
+data "nhncloud_networking_vpcsubnets_v2" "all" {
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+}
+
+data "nhncloud_networking_vpcsubnets_v2" "regex" {
+  vpc_id     = nhncloud_networking_vpc_v2.vpc_1.id
+  name_regex = "^subnet-"
+}
+
+data "nhncloud_networking_vpcsubnets_v2" "routingtable" {
+  routingtable_id = nhncloud_networking_routingtable_v2.routingtable_1.id
+}
+`, testUnitNetworkingV2VPCSubnetsDataSourceResources)
@@ -59,6 +59,11 @@ func dataSourceBlockStorageSnapshotV2() *schema.Resource {
 				Type:     schema.TypeMap,
 				Computed: true,
 			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -109,6 +114,13 @@ func dataSourceBlockStorageSnapshotV2Read(ctx context.Context, d *schema.Resourc
 
 	dataSourceBlockStorageSnapshotV2Attributes(d, snapshot)
 
+	// A snapshot is encrypted when its volume is.
+	encrypted, err := blockStorageVolumeEncrypted(client, snapshot.VolumeID)
+	if err != nil {
+		return diag.Errorf("Unable to get the encryption status of openstack_blockstorage_snapshot_v2 %s: %s", snapshot.ID, err)
+	}
+	d.Set("encrypted", encrypted)
+
 	return nil
 }
 
@@ -59,6 +59,11 @@ func dataSourceBlockStorageSnapshotV3() *schema.Resource {
 				Type:     schema.TypeMap,
 				Computed: true,
 			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -109,6 +114,13 @@ func dataSourceBlockStorageSnapshotV3Read(ctx context.Context, d *schema.Resourc
 
 	dataSourceBlockStorageSnapshotV3Attributes(d, snapshot)
 
+	// A snapshot is encrypted when its volume is.
+	encrypted, err := blockStorageVolumeEncrypted(client, snapshot.VolumeID)
+	if err != nil {
+		return diag.Errorf("Unable to get the encryption status of nhncloud_blockstorage_snapshot_v3 %s: %s", snapshot.ID, err)
+	}
+	d.Set("encrypted", encrypted)
+
 	return nil
 }
 
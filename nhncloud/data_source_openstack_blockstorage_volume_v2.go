@@ -61,6 +61,11 @@ func dataSourceBlockStorageVolumeV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -109,6 +114,7 @@ func dataSourceBlockStorageVolumeV2Attributes(d *schema.ResourceData, volume vol
 	d.Set("volume_type", volume.VolumeType)
 	d.Set("size", volume.Size)
 	d.Set("source_volume_id", volume.SourceVolID)
+	d.Set("encrypted", volume.Encrypted)
 
 	if err := d.Set("metadata", volume.Metadata); err != nil {
 		log.Printf("[DEBUG] Unable to set metadata for volume %s: %s", volume.ID, err)
@@ -63,6 +63,11 @@ func dataSourceBlockStorageVolumeV3() *schema.Resource {
 				Computed: true,
 			},
 
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
 			"multiattach": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -127,6 +132,7 @@ func dataSourceBlockStorageVolumeV3Attributes(d *schema.ResourceData, volume Vol
 	d.Set("volume_type", volume.VolumeType)
 	d.Set("size", volume.Size)
 	d.Set("source_volume_id", volume.SourceVolID)
+	d.Set("encrypted", volume.Encrypted)
 	d.Set("multiattach", volume.Multiattach)
 	d.Set("host", volume.Host)
 
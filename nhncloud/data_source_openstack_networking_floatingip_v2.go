@@ -118,7 +118,7 @@ func dataSourceNetworkingFloatingIPV2Read(ctx context.Context, d *schema.Resourc
 		listOpts.Status = v.(string)
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
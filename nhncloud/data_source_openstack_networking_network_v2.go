@@ -187,7 +187,7 @@ func dataSourceNetworkingNetworkV2Read(ctx context.Context, d *schema.ResourceDa
 		}
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts = networks.ListOpts{Tags: strings.Join(tags, ",")}
 	}
@@ -197,7 +197,7 @@ func dataSourceNetworkingPortIDsV2Read(ctx context.Context, d *schema.ResourceDa
 		listOpts.DeviceID = v.(string)
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
@@ -254,7 +254,7 @@ func dataSourceNetworkingPortV2Read(ctx context.Context, d *schema.ResourceData,
 		listOpts.DeviceID = v.(string)
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
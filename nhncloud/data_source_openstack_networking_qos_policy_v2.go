@@ -122,7 +122,7 @@ func dataSourceNetworkingQoSPolicyV2Read(ctx context.Context, d *schema.Resource
 		listOpts.IsDefault = &isDefault
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
@@ -204,7 +204,7 @@ func dataSourceNetworkingSubnetPoolV2Read(ctx context.Context, d *schema.Resourc
 		listOpts.IsDefault = &isDefault
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
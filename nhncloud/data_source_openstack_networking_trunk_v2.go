@@ -132,7 +132,7 @@ func dataSourceNetworkingTrunkV2Read(ctx context.Context, d *schema.ResourceData
 		listOpts.Status = v.(string)
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, nil)
 	if len(tags) > 0 {
 		listOpts.Tags = strings.Join(tags, ",")
 	}
@@ -0,0 +1,346 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// blockStorageCollections are served under /volume/v2/{project_id} and
+// /volume/v3/{project_id}. Both versions share the same state.
+var blockStorageCollections = map[string]*collection{
+	"volumes": {
+		kind:       "volumes",
+		singular:   "volume",
+		plural:     "volumes",
+		createCode: http.StatusAccepted,
+		deleteCode: http.StatusAccepted,
+		create:     createVolume,
+		remove:     removeVolume,
+		actions: map[string]actionFunc{
+			"action":   volumeAction,
+			"metadata": volumeMetadata,
+		},
+	},
+	"snapshots": {
+		kind:       "snapshots",
+		singular:   "snapshot",
+		plural:     "snapshots",
+		createCode: http.StatusAccepted,
+		deleteCode: http.StatusAccepted,
+		create:     createSnapshot,
+		actions: map[string]actionFunc{
+			"metadata": volumeMetadata,
+		},
+	},
+	"backups": {
+		kind:       "backups",
+		singular:   "backup",
+		plural:     "backups",
+		createCode: http.StatusAccepted,
+		deleteCode: http.StatusAccepted,
+		create:     createBackup,
+		remove:     removeBackup,
+		render:     renderBackup,
+		actions: map[string]actionFunc{
+			"restore": backupRestore,
+		},
+	},
+}
+
+// cinderTimeFormat is the timestamp format of the block storage API, which
+// has no time zone.
+const cinderTimeFormat = "2006-01-02T15:04:05.000000"
+
+func cinderNow() string {
+	return time.Now().UTC().Format(cinderTimeFormat)
+}
+
+func (s *Server) serveBlockStorage(w http.ResponseWriter, r *http.Request) {
+	// /volume/v3/{project_id}/<collection>/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 4 {
+		writeError(w, http.StatusNotFound, "unknown volume path")
+		return
+	}
+
+	serveCollections(s, w, r, blockStorageCollections, segs[3:])
+}
+
+func createVolume(s *Server, obj map[string]interface{}) string {
+	if snapshotID, _ := obj["snapshot_id"].(string); snapshotID != "" {
+		snapshot, ok := s.getLocked("snapshots", snapshotID)
+		if !ok {
+			return fmt.Sprintf("Snapshot %s could not be found.", snapshotID)
+		}
+		setDefault(obj, "size", snapshot["size"])
+		if volume, ok := s.getLocked("volumes", fmt.Sprint(snapshot["volume_id"])); ok {
+			setDefault(obj, "encrypted", volume["encrypted"])
+		}
+	}
+
+	if sourceID, _ := obj["source_volid"].(string); sourceID != "" {
+		source, ok := s.getLocked("volumes", sourceID)
+		if !ok {
+			return fmt.Sprintf("Volume %s could not be found.", sourceID)
+		}
+		setDefault(obj, "size", source["size"])
+		setDefault(obj, "encrypted", source["encrypted"])
+	}
+
+	// The SKM key of nhn_encryption is not returned.
+	if enc, ok := obj["nhn_encryption"].(map[string]interface{}); ok {
+		if enc["skm_appkey"] == nil || enc["skm_appkey"] == "" || enc["skm_key_id"] == nil || enc["skm_key_id"] == "" {
+			return "Invalid input for field/attribute nhn_encryption. skm_appkey and skm_key_id are required."
+		}
+		obj["encrypted"] = true
+		delete(obj, "nhn_encryption")
+	}
+
+	if obj["size"] == nil {
+		return "Invalid input for field/attribute size."
+	}
+
+	setDefault(obj, "status", "available")
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "volume_type", "General HDD")
+	setDefault(obj, "availability_zone", "nova")
+	setDefault(obj, "bootable", "false")
+	setDefault(obj, "encrypted", false)
+	setDefault(obj, "multiattach", false)
+	setDefault(obj, "metadata", map[string]interface{}{})
+	setDefault(obj, "attachments", []interface{}{})
+	obj["os-vol-tenant-attr:tenant_id"] = s.ProjectID
+	obj["created_at"] = cinderNow()
+	obj["updated_at"] = cinderNow()
+
+	return ""
+}
+
+func removeVolume(s *Server, obj map[string]interface{}) string {
+	if obj["status"] == "in-use" {
+		return fmt.Sprintf("Volume %s is still attached, detach volume first.", obj["id"])
+	}
+
+	for _, snapshot := range s.store["snapshots"] {
+		if snapshot["volume_id"] == obj["id"] {
+			return fmt.Sprintf("Volume %s has dependent snapshot %s.", obj["id"], snapshot["id"])
+		}
+	}
+
+	return ""
+}
+
+// volumeAction handles POST /volumes/{id}/action. Every action completes
+// immediately.
+func volumeAction(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	switch {
+	case hasKey(body, "os-extend"):
+		extend, _ := body["os-extend"].(map[string]interface{})
+		obj["size"] = extend["new_size"]
+	case hasKey(body, "os-set_bootable"):
+		bootable, _ := body["os-set_bootable"].(map[string]interface{})
+		obj["bootable"] = fmt.Sprint(bootable["bootable"])
+	case hasKey(body, "os-retype"):
+		retype, _ := body["os-retype"].(map[string]interface{})
+		obj["volume_type"] = retype["new_type"]
+	case hasKey(body, "os-reserve"):
+		obj["status"] = "attaching"
+	case hasKey(body, "os-unreserve"):
+		obj["status"] = "available"
+	case hasKey(body, "os-attach"):
+		attach, _ := body["os-attach"].(map[string]interface{})
+		obj["status"] = "in-use"
+		obj["attachments"] = []interface{}{
+			map[string]interface{}{"server_id": attach["instance_uuid"], "volume_id": obj["id"], "device": attach["mountpoint"]},
+		}
+	case hasKey(body, "os-detach"), hasKey(body, "os-force_detach"):
+		obj["status"] = "available"
+		obj["attachments"] = []interface{}{}
+	case hasKey(body, "revert"):
+		revert, _ := body["revert"].(map[string]interface{})
+		snapshotID, _ := revert["snapshot_id"].(string)
+		snapshot, ok := s.getLocked("snapshots", snapshotID)
+		if !ok || snapshot["volume_id"] != obj["id"] {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, "Snapshot "+snapshotID+" does not belong to the volume.")
+		}
+		if obj["status"] != "available" {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, fmt.Sprintf("Volume %s status must be available to revert, but is %v.", obj["id"], obj["status"]))
+		}
+		obj["size"] = snapshot["size"]
+	default:
+		return http.StatusBadRequest, errorBody(http.StatusBadRequest, "unsupported volume action")
+	}
+
+	obj["updated_at"] = cinderNow()
+
+	return http.StatusAccepted, nil
+}
+
+// volumeMetadata handles /volumes/{id}/metadata and
+// /snapshots/{id}/metadata.
+func volumeMetadata(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+
+	switch body["_method"] {
+	case http.MethodPut:
+		metadata, _ = body["metadata"].(map[string]interface{})
+	case http.MethodPost:
+		changes, _ := body["metadata"].(map[string]interface{})
+		for k, v := range changes {
+			metadata[k] = v
+		}
+	case http.MethodDelete:
+		delete(metadata, fmt.Sprint(body["_subpath"]))
+		obj["metadata"] = metadata
+		return http.StatusOK, nil
+	}
+	obj["metadata"] = metadata
+
+	return http.StatusOK, map[string]interface{}{"metadata": metadata}
+}
+
+func createSnapshot(s *Server, obj map[string]interface{}) string {
+	volumeID, _ := obj["volume_id"].(string)
+	volume, ok := s.getLocked("volumes", volumeID)
+	if !ok {
+		return fmt.Sprintf("Volume %s could not be found.", volumeID)
+	}
+
+	if volume["status"] == "in-use" && obj["force"] != true {
+		return fmt.Sprintf("Volume %s is in-use, set force to snapshot it.", volumeID)
+	}
+
+	obj["size"] = volume["size"]
+	obj["status"] = "available"
+	obj["created_at"] = cinderNow()
+	obj["updated_at"] = cinderNow()
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "metadata", map[string]interface{}{})
+	delete(obj, "force")
+
+	return ""
+}
+
+func createBackup(s *Server, obj map[string]interface{}) string {
+	volumeID, _ := obj["volume_id"].(string)
+	volume, ok := s.getLocked("volumes", volumeID)
+	if !ok {
+		return fmt.Sprintf("Volume %s could not be found.", volumeID)
+	}
+
+	if volume["status"] == "in-use" && obj["force"] != true {
+		return fmt.Sprintf("Volume %s is in-use, set force to back it up.", volumeID)
+	}
+
+	// An incremental backup is based on the latest backup of the volume.
+	if obj["incremental"] == true {
+		var parent map[string]interface{}
+		for _, backup := range s.listLocked("backups") {
+			if backup["volume_id"] == volumeID && (parent == nil || fmt.Sprint(backup["created_at"]) >= fmt.Sprint(parent["created_at"])) {
+				parent = backup
+			}
+		}
+		if parent == nil {
+			return "No backups available to do an incremental backup."
+		}
+		obj["parent_id"] = parent["id"]
+	}
+
+	obj["is_incremental"] = obj["incremental"] == true
+	obj["size"] = volume["size"]
+	obj["status"] = "available"
+	obj["object_count"] = 1
+	obj["created_at"] = cinderNow()
+	obj["updated_at"] = cinderNow()
+	obj["data_timestamp"] = obj["created_at"]
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "container", "volumebackups")
+	setDefault(obj, "metadata", map[string]interface{}{})
+	delete(obj, "incremental")
+	delete(obj, "force")
+
+	return ""
+}
+
+func removeBackup(s *Server, obj map[string]interface{}) string {
+	for _, backup := range s.store["backups"] {
+		if backup["parent_id"] == obj["id"] {
+			return fmt.Sprintf("Incremental backups exist for backup %s.", obj["id"])
+		}
+	}
+
+	return ""
+}
+
+func renderBackup(s *Server, obj map[string]interface{}) map[string]interface{} {
+	obj["has_dependent_backups"] = false
+	for _, backup := range s.store["backups"] {
+		if backup["parent_id"] == obj["id"] {
+			obj["has_dependent_backups"] = true
+		}
+	}
+
+	return obj
+}
+
+// backupRestore handles POST /backups/{id}/restore. A new volume is created
+// unless the request names an existing one.
+func backupRestore(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	restore, _ := body["restore"].(map[string]interface{})
+	volumeID, _ := restore["volume_id"].(string)
+
+	if volumeID == "" {
+		volume := map[string]interface{}{
+			"size": obj["size"],
+			"name": restore["name"],
+		}
+		if volume["name"] == nil {
+			volume["name"] = "restore_backup_" + fmt.Sprint(obj["id"])
+		}
+		if msg := createVolume(s, volume); msg != "" {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, msg)
+		}
+		volumeID = s.putLocked("volumes", volume)
+	}
+
+	volume, ok := s.getLocked("volumes", volumeID)
+	if !ok {
+		return http.StatusNotFound, errorBody(http.StatusNotFound, fmt.Sprintf("Volume %s could not be found.", volumeID))
+	}
+	if volume["status"] != "available" {
+		return http.StatusBadRequest, errorBody(http.StatusBadRequest, fmt.Sprintf("Volume %s status must be available to restore, but is %v.", volumeID, volume["status"]))
+	}
+	if number(volume["size"]) < number(obj["size"]) {
+		return http.StatusBadRequest, errorBody(http.StatusBadRequest, fmt.Sprintf("Volume %s is smaller than backup %s.", volumeID, obj["id"]))
+	}
+
+	volume["updated_at"] = cinderNow()
+
+	return http.StatusAccepted, map[string]interface{}{
+		"restore": map[string]interface{}{
+			"backup_id":   obj["id"],
+			"volume_id":   volumeID,
+			"volume_name": volume["name"],
+		},
+	}
+}
+
+// number returns a numeric field, which is a float64 when it was decoded
+// from a request and an int when a test stored it with Put.
+func number(v interface{}) float64 {
+	switch n := v.(type) {
+	case float64:
+		return n
+	case int:
+		return float64(n)
+	}
+
+	return 0
+}
@@ -0,0 +1,324 @@
+package mockcloud
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// collection describes how a REST collection is exposed by a fake service.
+type collection struct {
+	// kind is the key the objects are stored under.
+	kind string
+
+	// singular and plural are the JSON envelope keys for a single object and
+	// for a list. An empty singular means the object is not wrapped, as in
+	// the Magnum API.
+	singular string
+	plural   string
+
+	// createCode, updateCode and deleteCode override the status codes
+	// returned by POST, PUT/PATCH and DELETE. They default to 201, 200 and 204.
+	createCode int
+	updateCode int
+	deleteCode int
+
+	// idKey is the field holding the object ID. It defaults to "id".
+	idKey string
+
+	// create fills in server-side defaults on a new object. It is called
+	// with the server lock held and may return an error message to reject
+	// the request with 400.
+	create func(s *Server, obj map[string]interface{}) string
+
+	// update is called with the server lock held after the request fields
+	// were merged into the stored object.
+	update func(s *Server, obj map[string]interface{}, changes map[string]interface{}) string
+
+	// remove is called with the server lock held before the object is
+	// removed. It may return an error message to reject the request with 409.
+	remove func(s *Server, obj map[string]interface{}) string
+
+	// render decorates a copy of the object before it is returned. It is
+	// called with the server lock held.
+	render func(s *Server, obj map[string]interface{}) map[string]interface{}
+
+	// actions handle sub-paths of an object, such as
+	// "/vpcsubnets/{id}/attach_routingtable". They are called with the
+	// server lock held.
+	actions map[string]actionFunc
+}
+
+// actionFunc handles a sub-path of an object. body holds the decoded request
+// body plus the "_method" and, for nested paths, "_subpath" keys.
+type actionFunc func(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{})
+
+// headerResponse lets an action set response headers, such as the Location
+// of an image created from a server.
+type headerResponse struct {
+	header map[string]string
+	body   interface{}
+}
+
+// ignoredFilters are query parameters that never filter a list.
+var ignoredFilters = map[string]bool{
+	"limit":        true,
+	"marker":       true,
+	"fields":       true,
+	"sort_key":     true,
+	"sort_dir":     true,
+	"page_reverse": true,
+}
+
+// serve dispatches a request whose path, relative to the collection, is segs.
+func (c *collection) serve(s *Server, w http.ResponseWriter, r *http.Request, segs []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(segs) == 0 || (len(segs) == 1 && segs[0] == "detail" && r.Method == http.MethodGet):
+		switch r.Method {
+		case http.MethodGet:
+			c.list(s, w, r.URL.Query())
+		case http.MethodPost:
+			c.post(s, w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+		}
+		return
+	}
+
+	obj, ok := s.getLocked(c.kind, segs[0])
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s could not be found.", c.singularName(), segs[0]))
+		return
+	}
+
+	if len(segs) > 1 {
+		action, ok := c.actions[segs[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("unknown action %q", segs[1]))
+			return
+		}
+
+		body := make(map[string]interface{})
+		if r.Header.Get("Content-Type") != "application/octet-stream" {
+			var err error
+			if body, err = readBody(r); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		body["_method"] = r.Method
+		if len(segs) > 2 {
+			body["_subpath"] = segs[2]
+		}
+
+		code, resp := action(s, obj, body)
+		if hr, ok := resp.(headerResponse); ok {
+			for k, v := range hr.header {
+				w.Header().Set(k, v)
+			}
+			resp = hr.body
+		}
+		writeJSON(w, code, resp)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.wrap(c.renderObject(s, obj)))
+	case http.MethodPut, http.MethodPatch:
+		changes, err := c.readChanges(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(obj, k)
+				continue
+			}
+			obj[k] = v
+		}
+		if c.update != nil {
+			if msg := c.update(s, obj, changes); msg != "" {
+				writeError(w, http.StatusBadRequest, msg)
+				return
+			}
+		}
+		code := c.updateCode
+		if code == 0 {
+			code = http.StatusOK
+		}
+		writeJSON(w, code, c.wrap(c.renderObject(s, obj)))
+	case http.MethodDelete:
+		if c.remove != nil {
+			if msg := c.remove(s, obj); msg != "" {
+				writeError(w, http.StatusConflict, msg)
+				return
+			}
+		}
+		delete(s.store[c.kind], segs[0])
+		code := c.deleteCode
+		if code == 0 {
+			code = http.StatusNoContent
+		}
+		writeJSON(w, code, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+func (c *collection) list(s *Server, w http.ResponseWriter, query url.Values) {
+	items := make([]interface{}, 0)
+	for _, obj := range s.listLocked(c.kind) {
+		if !matchesFilters(obj, query) {
+			continue
+		}
+		items = append(items, c.renderObject(s, obj))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{c.plural: items})
+}
+
+func (c *collection) post(s *Server, w http.ResponseWriter, r *http.Request) {
+	body, err := readBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	obj := c.unwrap(body)
+
+	// Objects created with a token scoped to another project belong to
+	// that project.
+	if projectID := s.requestProject(r); projectID != s.ProjectID {
+		setDefault(obj, "tenant_id", projectID)
+	}
+
+	if c.create != nil {
+		if msg := c.create(s, obj); msg != "" {
+			writeError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	id := s.putLocked(c.kind, obj)
+	if c.idKey != "" {
+		obj[c.idKey] = id
+	}
+
+	code := c.createCode
+	if code == 0 {
+		code = http.StatusCreated
+	}
+
+	writeJSON(w, code, c.wrap(c.renderObject(s, obj)))
+}
+
+// readChanges decodes the fields to update from a PUT or PATCH request. Magnum
+// updates are JSON patch documents, which are flattened into top-level fields,
+// with removed fields set to nil.
+func (c *collection) readChanges(r *http.Request) (map[string]interface{}, error) {
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.HasPrefix(strings.TrimSpace(string(b)), "[") {
+		body := make(map[string]interface{})
+		if len(b) > 0 {
+			if err := json.Unmarshal(b, &body); err != nil {
+				return nil, err
+			}
+		}
+		return c.unwrap(body), nil
+	}
+
+	var patch []struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}
+	if err := json.Unmarshal(b, &patch); err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]interface{})
+	for _, p := range patch {
+		key := strings.TrimPrefix(p.Path, "/")
+		if p.Op == "remove" {
+			changes[key] = nil
+			continue
+		}
+		changes[key] = p.Value
+	}
+
+	return changes, nil
+}
+
+func (c *collection) renderObject(s *Server, obj map[string]interface{}) map[string]interface{} {
+	out := copyObject(obj)
+	if c.idKey != "" {
+		delete(out, "id")
+	}
+	if c.render != nil {
+		out = c.render(s, out)
+	}
+
+	return out
+}
+
+func (c *collection) wrap(obj map[string]interface{}) interface{} {
+	if c.singular == "" {
+		return obj
+	}
+
+	return map[string]interface{}{c.singular: obj}
+}
+
+func (c *collection) unwrap(body map[string]interface{}) map[string]interface{} {
+	if c.singular == "" {
+		return body
+	}
+
+	if inner, ok := body[c.singular].(map[string]interface{}); ok {
+		return inner
+	}
+
+	return make(map[string]interface{})
+}
+
+func (c *collection) singularName() string {
+	if c.singular != "" {
+		return c.singular
+	}
+
+	return c.kind
+}
+
+// matchesFilters reports whether obj satisfies every query filter. Filters on
+// fields the object does not have are ignored, which keeps the fake lenient
+// towards the many optional list options of the real APIs.
+func matchesFilters(obj map[string]interface{}, query url.Values) bool {
+	for key, values := range query {
+		if ignoredFilters[key] || len(values) == 0 || values[0] == "" {
+			continue
+		}
+
+		v, ok := obj[key]
+		if !ok {
+			continue
+		}
+
+		if fmt.Sprint(v) != values[0] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,511 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// serverCollection serves both /servers and the NHN Cloud boot-from-volume
+// alias /os-volumes_boot.
+var serverCollection = &collection{
+	kind:       "servers",
+	singular:   "server",
+	plural:     "servers",
+	createCode: http.StatusAccepted,
+	create:     createServer,
+	remove:     removeServer,
+	render:     renderServer,
+	actions: map[string]actionFunc{
+		"action":             serverAction,
+		"tags":               serverTags,
+		"metadata":           serverMetadata,
+		"os-server-password": serverPassword,
+	},
+}
+
+// computeCollections are served under /compute/v2.1.
+var computeCollections = map[string]*collection{
+	"servers":         serverCollection,
+	"os-volumes_boot": serverCollection,
+	"flavors": {
+		kind:     "flavors",
+		singular: "flavor",
+		plural:   "flavors",
+		create:   createFlavor,
+		render:   renderFlavor,
+	},
+	"images": {
+		kind:     "images",
+		singular: "image",
+		plural:   "images",
+		render:   renderComputeImage,
+	},
+}
+
+func (s *Server) serveCompute(w http.ResponseWriter, r *http.Request) {
+	// /compute/v2.1/<collection>/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 3 {
+		writeError(w, http.StatusNotFound, "unknown compute path")
+		return
+	}
+
+	serveCollections(s, w, r, computeCollections, segs[2:])
+}
+
+func createServer(s *Server, obj map[string]interface{}) string {
+	flavorID, _ := obj["flavorRef"].(string)
+	if _, ok := s.getLocked("flavors", flavorID); !ok {
+		return fmt.Sprintf("Flavor %s could not be found.", flavorID)
+	}
+	obj["flavor"] = map[string]interface{}{"id": flavorID}
+
+	obj["image"] = ""
+	if imageID, _ := obj["imageRef"].(string); imageID != "" {
+		if _, ok := s.getLocked("images", imageID); !ok {
+			return fmt.Sprintf("Image %s could not be found.", imageID)
+		}
+		obj["image"] = map[string]interface{}{"id": imageID}
+	}
+
+	obj["id"] = newID()
+
+	addresses, msg := s.attachServerNetworks(obj)
+	if msg != "" {
+		return msg
+	}
+	obj["addresses"] = addresses
+
+	obj["os-extended-volumes:volumes_attached"] = s.attachServerVolumes(obj)
+
+	if _, ok := obj["security_groups"]; !ok {
+		obj["security_groups"] = []interface{}{map[string]interface{}{"name": "default"}}
+	}
+
+	setDefault(obj, "metadata", map[string]interface{}{})
+	setDefault(obj, "tags", []interface{}{})
+	setDefault(obj, "key_name", "")
+	setDefault(obj, "availability_zone", "nova")
+	obj["OS-EXT-AZ:availability_zone"] = obj["availability_zone"]
+	obj["tenant_id"] = s.ProjectID
+	obj["user_id"] = "mock-user-id"
+	obj["created"] = now()
+	obj["updated"] = now()
+	setServerStatus(obj, "ACTIVE")
+
+	if userData, ok := obj["user_data"]; ok {
+		obj["OS-EXT-SRV-ATTR:user_data"] = userData
+	}
+
+	for _, k := range []string{"flavorRef", "imageRef", "networks", "block_device_mapping_v2", "adminPass", "user_data", "availability_zone"} {
+		delete(obj, k)
+	}
+
+	return ""
+}
+
+// attachServerNetworks creates a port for every requested network, or takes
+// over the requested port, and returns the Nova addresses of the server.
+func (s *Server) attachServerNetworks(obj map[string]interface{}) (map[string]interface{}, string) {
+	networks, _ := obj["networks"].([]interface{})
+	if len(networks) == 0 {
+		for _, vpc := range s.listLocked("vpcs") {
+			if vpc["name"] == DefaultNetworkName {
+				networks = []interface{}{map[string]interface{}{"uuid": vpc["id"]}}
+			}
+		}
+	}
+
+	addresses := make(map[string]interface{})
+	for _, n := range networks {
+		network, _ := n.(map[string]interface{})
+
+		var port map[string]interface{}
+		if portID, _ := network["port"].(string); portID != "" {
+			p, ok := s.getLocked("ports", portID)
+			if !ok {
+				return nil, fmt.Sprintf("Port %s could not be found.", portID)
+			}
+			port = p
+		} else {
+			vpcID, _ := network["uuid"].(string)
+			if _, ok := s.getLocked("vpcs", vpcID); !ok {
+				return nil, fmt.Sprintf("Network %s could not be found.", vpcID)
+			}
+
+			port = map[string]interface{}{
+				"network_id":   vpcID,
+				"device_owner": "compute:nova",
+			}
+			if ip, _ := network["fixed_ip"].(string); ip != "" {
+				for _, subnet := range s.listLocked("vpcsubnets") {
+					if subnet["vpc_id"] == vpcID {
+						port["fixed_ips"] = []interface{}{
+							map[string]interface{}{"subnet_id": subnet["id"], "ip_address": ip},
+						}
+						break
+					}
+				}
+			}
+			createPort(s, port)
+			s.putLocked("ports", port)
+		}
+		port["device_id"] = obj["id"]
+
+		vpc, _ := s.getLocked("vpcs", fmt.Sprint(port["network_id"]))
+		name := fmt.Sprint(vpc["name"])
+
+		ips, _ := port["fixed_ips"].([]interface{})
+		for _, ip := range ips {
+			m, _ := ip.(map[string]interface{})
+			entries, _ := addresses[name].([]interface{})
+			addresses[name] = append(entries, map[string]interface{}{
+				"addr":                    m["ip_address"],
+				"version":                 4,
+				"OS-EXT-IPS:type":         "fixed",
+				"OS-EXT-IPS-MAC:mac_addr": port["mac_address"],
+			})
+		}
+	}
+
+	return addresses, ""
+}
+
+// attachServerVolumes creates the volumes of block device mappings that
+// target a volume and returns the attached volume list of the server.
+func (s *Server) attachServerVolumes(obj map[string]interface{}) []interface{} {
+	attached := make([]interface{}, 0)
+
+	bdms, _ := obj["block_device_mapping_v2"].([]interface{})
+	for _, b := range bdms {
+		bdm, _ := b.(map[string]interface{})
+		if bdm["destination_type"] != "volume" {
+			continue
+		}
+
+		volumeID, _ := bdm["uuid"].(string)
+		if bdm["source_type"] != "volume" {
+			volume := map[string]interface{}{
+				"size":                  bdm["volume_size"],
+				"volume_type":           bdm["volume_type"],
+				"status":                "in-use",
+				"bootable":              "true",
+				"delete_on_termination": bdm["delete_on_termination"],
+			}
+			createVolume(s, volume)
+			volumeID = s.putLocked("volumes", volume)
+		}
+
+		if volume, ok := s.getLocked("volumes", volumeID); ok {
+			volume["status"] = "in-use"
+			volume["attachments"] = []interface{}{
+				map[string]interface{}{"server_id": obj["id"], "volume_id": volumeID, "device": "/dev/vda"},
+			}
+		}
+
+		attached = append(attached, map[string]interface{}{"id": volumeID})
+	}
+
+	return attached
+}
+
+func removeServer(s *Server, obj map[string]interface{}) string {
+	for id, port := range s.store["ports"] {
+		if port["device_id"] != obj["id"] {
+			continue
+		}
+		if port["device_owner"] == "compute:nova" {
+			delete(s.store["ports"], id)
+		} else {
+			port["device_id"] = ""
+		}
+	}
+
+	attached, _ := obj["os-extended-volumes:volumes_attached"].([]interface{})
+	for _, a := range attached {
+		id := fmt.Sprint(a.(map[string]interface{})["id"])
+		volume, ok := s.getLocked("volumes", id)
+		if !ok {
+			continue
+		}
+		if volume["delete_on_termination"] == true {
+			delete(s.store["volumes"], id)
+			continue
+		}
+		volume["status"] = "available"
+		volume["attachments"] = []interface{}{}
+	}
+
+	return ""
+}
+
+func renderServer(s *Server, obj map[string]interface{}) map[string]interface{} {
+	for k := range obj {
+		if strings.HasPrefix(k, "_") {
+			delete(obj, k)
+		}
+	}
+
+	return obj
+}
+
+func setServerStatus(obj map[string]interface{}, status string) {
+	obj["status"] = status
+	obj["updated"] = now()
+
+	switch status {
+	case "SHUTOFF":
+		obj["OS-EXT-STS:vm_state"] = "stopped"
+		obj["OS-EXT-STS:power_state"] = 4
+	case "VERIFY_RESIZE":
+		obj["OS-EXT-STS:vm_state"] = "resized"
+		obj["OS-EXT-STS:power_state"] = 1
+	default:
+		obj["OS-EXT-STS:vm_state"] = strings.ToLower(status)
+		obj["OS-EXT-STS:power_state"] = 1
+	}
+}
+
+// serverAction handles POST /servers/{id}/action. Every action completes
+// immediately.
+func serverAction(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	switch {
+	case hasKey(body, "os-stop"):
+		setServerStatus(obj, "SHUTOFF")
+	case hasKey(body, "os-start"):
+		setServerStatus(obj, "ACTIVE")
+	case hasKey(body, "shelve"):
+		setServerStatus(obj, "SHELVED_OFFLOADED")
+	case hasKey(body, "unshelve"):
+		setServerStatus(obj, "ACTIVE")
+	case hasKey(body, "resize"):
+		resize, _ := body["resize"].(map[string]interface{})
+		flavorID, _ := resize["flavorRef"].(string)
+		flavor, ok := s.getLocked("flavors", flavorID)
+		if !ok {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, "Flavor "+flavorID+" could not be found.")
+		}
+		if obj["status"] != "ACTIVE" && obj["status"] != "SHUTOFF" {
+			return http.StatusConflict, errorBody(http.StatusConflict, fmt.Sprintf("Cannot 'resize' instance %s while it is in vm_state %v", obj["id"], obj["OS-EXT-STS:vm_state"]))
+		}
+		// NHN Cloud does not move an instance between flavors with a local
+		// root disk and flavors that boot from block storage.
+		current, _ := obj["flavor"].(map[string]interface{})
+		if currentFlavor, ok := s.getLocked("flavors", fmt.Sprint(current["id"])); ok && (number(currentFlavor["disk"]) > 0) != (number(flavor["disk"]) > 0) {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, "Resizing between local disk and block storage flavors is not supported.")
+		}
+		obj["_previous_flavor"] = obj["flavor"]
+		obj["_previous_status"] = obj["status"]
+		obj["flavor"] = map[string]interface{}{"id": flavorID}
+		setServerStatus(obj, "VERIFY_RESIZE")
+	case hasKey(body, "confirmResize") || hasKey(body, "revertResize"):
+		if obj["status"] != "VERIFY_RESIZE" {
+			return http.StatusConflict, errorBody(http.StatusConflict, "Instance is not in VERIFY_RESIZE state")
+		}
+		// Tests set _confirm_resize_error to make the next confirmation fail.
+		if msg, ok := obj["_confirm_resize_error"].(string); ok && hasKey(body, "confirmResize") {
+			delete(obj, "_confirm_resize_error")
+			return http.StatusInternalServerError, errorBody(http.StatusInternalServerError, msg)
+		}
+		if hasKey(body, "revertResize") {
+			obj["flavor"] = obj["_previous_flavor"]
+		}
+		status, _ := obj["_previous_status"].(string)
+		if status == "" {
+			status = "ACTIVE"
+		}
+		delete(obj, "_previous_flavor")
+		delete(obj, "_previous_status")
+		setServerStatus(obj, status)
+		if hasKey(body, "confirmResize") {
+			return http.StatusNoContent, nil
+		}
+	case hasKey(body, "rebuild"):
+		rebuild, _ := body["rebuild"].(map[string]interface{})
+		imageID, _ := rebuild["imageRef"].(string)
+		if _, ok := s.getLocked("images", imageID); !ok {
+			return http.StatusBadRequest, errorBody(http.StatusBadRequest, "Image "+imageID+" could not be found.")
+		}
+		obj["image"] = map[string]interface{}{"id": imageID}
+		if name, ok := rebuild["name"]; ok {
+			obj["name"] = name
+		}
+		if metadata, ok := rebuild["metadata"]; ok {
+			obj["metadata"] = metadata
+		}
+		if userData, ok := rebuild["user_data"]; ok {
+			obj["OS-EXT-SRV-ATTR:user_data"] = userData
+		}
+		setServerStatus(obj, "ACTIVE")
+		return http.StatusAccepted, map[string]interface{}{"server": renderServer(s, copyObject(obj))}
+	case hasKey(body, "createImage"):
+		createImage, _ := body["createImage"].(map[string]interface{})
+		// Tests set _create_image_error to make the snapshot fail.
+		if msg, ok := obj["_create_image_error"].(string); ok && msg != "" {
+			return http.StatusConflict, errorBody(http.StatusConflict, msg)
+		}
+		image := map[string]interface{}{
+			"name":          createImage["name"],
+			"status":        "active",
+			"visibility":    "private",
+			"min_disk":      0,
+			"created_at":    now(),
+			"updated_at":    now(),
+			"instance_uuid": obj["id"],
+		}
+		if metadata, ok := createImage["metadata"].(map[string]interface{}); ok {
+			for k, v := range metadata {
+				image[k] = v
+			}
+		}
+		id := s.putLocked("images", image)
+		return http.StatusAccepted, headerResponse{
+			header: map[string]string{"Location": s.URL + "/image/v2/images/" + id},
+			body:   map[string]interface{}{"image_id": id},
+		}
+	case hasKey(body, "os-getConsoleOutput"):
+		output, _ := obj["_console_output"].(string)
+		if output == "" {
+			output = fmt.Sprintf("[    0.000000] Linux version 5.15.0 (mock)\n%s login:\n", obj["name"])
+		}
+		consoleOutput, _ := body["os-getConsoleOutput"].(map[string]interface{})
+		if length := int(number(consoleOutput["length"])); length > 0 {
+			lines := strings.SplitAfter(output, "\n")
+			if lines[len(lines)-1] == "" {
+				lines = lines[:len(lines)-1]
+			}
+			if len(lines) > length {
+				output = strings.Join(lines[len(lines)-length:], "")
+			}
+		}
+		return http.StatusOK, map[string]interface{}{"output": output}
+	case hasKey(body, "addSecurityGroup"):
+		group, _ := body["addSecurityGroup"].(map[string]interface{})
+		groups, _ := obj["security_groups"].([]interface{})
+		obj["security_groups"] = append(groups, map[string]interface{}{"name": group["name"]})
+	case hasKey(body, "removeSecurityGroup"):
+		group, _ := body["removeSecurityGroup"].(map[string]interface{})
+		groups, _ := obj["security_groups"].([]interface{})
+		kept := make([]interface{}, 0, len(groups))
+		for _, g := range groups {
+			if g.(map[string]interface{})["name"] != group["name"] {
+				kept = append(kept, g)
+			}
+		}
+		obj["security_groups"] = kept
+	case hasKey(body, "changePassword"):
+	case hasKey(body, "forceDelete"):
+		removeServer(s, obj)
+		delete(s.store["servers"], fmt.Sprint(obj["id"]))
+	default:
+		return http.StatusBadRequest, errorBody(http.StatusBadRequest, "unsupported server action")
+	}
+
+	return http.StatusAccepted, nil
+}
+
+// serverTags handles /servers/{id}/tags.
+func serverTags(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	switch body["_method"] {
+	case http.MethodPut:
+		if _, ok := body["_subpath"]; ok {
+			tags, _ := obj["tags"].([]interface{})
+			obj["tags"] = append(tags, body["_subpath"])
+			return http.StatusCreated, nil
+		}
+		obj["tags"] = body["tags"]
+		return http.StatusOK, map[string]interface{}{"tags": obj["tags"]}
+	case http.MethodDelete:
+		obj["tags"] = []interface{}{}
+		return http.StatusNoContent, nil
+	}
+
+	return http.StatusOK, map[string]interface{}{"tags": obj["tags"]}
+}
+
+// serverMetadata handles /servers/{id}/metadata and /metadata/{key}.
+func serverMetadata(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	metadata, _ := obj["metadata"].(map[string]interface{})
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+		obj["metadata"] = metadata
+	}
+
+	key, _ := body["_subpath"].(string)
+
+	switch body["_method"] {
+	case http.MethodPost, http.MethodPut:
+		if key != "" {
+			meta, _ := body["meta"].(map[string]interface{})
+			metadata[key] = meta[key]
+			return http.StatusOK, map[string]interface{}{"meta": map[string]interface{}{key: meta[key]}}
+		}
+		changes, _ := body["metadata"].(map[string]interface{})
+		if body["_method"] == http.MethodPut {
+			metadata = make(map[string]interface{})
+			obj["metadata"] = metadata
+		}
+		for k, v := range changes {
+			metadata[k] = v
+		}
+	case http.MethodDelete:
+		if _, ok := metadata[key]; !ok {
+			return http.StatusNotFound, errorBody(http.StatusNotFound, "Metadata item was not found")
+		}
+		delete(metadata, key)
+		return http.StatusNoContent, nil
+	}
+
+	if key != "" {
+		return http.StatusOK, map[string]interface{}{"meta": map[string]interface{}{key: metadata[key]}}
+	}
+
+	return http.StatusOK, map[string]interface{}{"metadata": metadata}
+}
+
+// serverPassword handles /servers/{id}/os-server-password. The encrypted
+// password can be seeded with Update("servers", id, {"_password": ...}).
+func serverPassword(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	if body["_method"] == http.MethodDelete {
+		delete(obj, "_password")
+		return http.StatusNoContent, nil
+	}
+
+	password, _ := obj["_password"].(string)
+
+	return http.StatusOK, map[string]interface{}{"password": password}
+}
+
+func createFlavor(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "disk", 0)
+	setDefault(obj, "vcpus", 1)
+	setDefault(obj, "ram", 1024)
+
+	return ""
+}
+
+func renderFlavor(s *Server, obj map[string]interface{}) map[string]interface{} {
+	setDefault(obj, "swap", "")
+	setDefault(obj, "rxtx_factor", 1.0)
+	setDefault(obj, "OS-FLV-EXT-DATA:ephemeral", 0)
+	setDefault(obj, "os-flavor-access:is_public", true)
+
+	return obj
+}
+
+// renderComputeImage presents a Glance image the way the Nova images proxy
+// API does.
+func renderComputeImage(s *Server, obj map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":       obj["id"],
+		"name":     obj["name"],
+		"status":   strings.ToUpper(fmt.Sprint(obj["status"])),
+		"minDisk":  obj["min_disk"],
+		"minRam":   0,
+		"progress": 100,
+		"created":  obj["created_at"],
+		"updated":  obj["updated_at"],
+		"metadata": map[string]interface{}{},
+	}
+}
@@ -0,0 +1,408 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// clusterCollection and nodeGroupCollection follow the Magnum API, which
+// returns unwrapped objects identified by "uuid".
+var clusterCollection = &collection{
+	kind:       "clusters",
+	plural:     "clusters",
+	createCode: http.StatusAccepted,
+	updateCode: http.StatusAccepted,
+	idKey:      "uuid",
+	create:     createCluster,
+	remove:     removeCluster,
+	update:     updateCluster,
+	actions: map[string]actionFunc{
+		"actions":      clusterActions,
+		"addons":       clusterAddons,
+		"api_ep_ipacl": clusterAPIEndpointIPACL,
+	},
+}
+
+var nodeGroupCollection = &collection{
+	kind:       "nodegroups",
+	plural:     "nodegroups",
+	createCode: http.StatusAccepted,
+	updateCode: http.StatusAccepted,
+	idKey:      "uuid",
+	create:     createNodeGroup,
+	update:     updateNodeGroup,
+	actions: map[string]actionFunc{
+		"autoscale": nodeGroupAutoscale,
+		"upgrade":   nodeGroupUpgrade,
+	},
+}
+
+var clusterTemplateCollection = &collection{
+	kind:   "clustertemplates",
+	plural: "clustertemplates",
+	idKey:  "uuid",
+	create: createClusterTemplate,
+}
+
+func (s *Server) serveContainerInfra(w http.ResponseWriter, r *http.Request) {
+	// /container-infra/v1/<collection>/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 3 {
+		writeError(w, http.StatusNotFound, "unknown container-infra path")
+		return
+	}
+	segs = segs[2:]
+
+	switch segs[0] {
+	case "clusters":
+		// /clusters/{cluster_id}/nodegroups/... is scoped to the cluster.
+		if len(segs) > 2 && segs[2] == "nodegroups" {
+			s.serveNodeGroups(w, r, segs[1], segs[3:])
+			return
+		}
+		clusterCollection.serve(s, w, r, segs[1:])
+	case "clustertemplates":
+		clusterTemplateCollection.serve(s, w, r, segs[1:])
+	case "addons":
+		s.serveAddonTypes(w, r)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown collection %q", segs[0]))
+	}
+}
+
+func (s *Server) serveNodeGroups(w http.ResponseWriter, r *http.Request, clusterID string, segs []string) {
+	if _, ok := s.Get("clusters", clusterID); !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("Cluster %s could not be found.", clusterID))
+		return
+	}
+
+	if len(segs) == 0 && r.Method == http.MethodGet {
+		q := r.URL.Query()
+		q.Set("cluster_id", clusterID)
+		r.URL.RawQuery = q.Encode()
+	}
+
+	if len(segs) > 0 {
+		// Node groups can be addressed by name, such as "default-master".
+		for _, ng := range s.List("nodegroups") {
+			if ng["cluster_id"] == clusterID && ng["name"] == segs[0] {
+				segs[0] = ng["id"].(string)
+				break
+			}
+		}
+		if ng, ok := s.Get("nodegroups", segs[0]); !ok || ng["cluster_id"] != clusterID {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("Nodegroup %s could not be found.", segs[0]))
+			return
+		}
+	}
+
+	c := *nodeGroupCollection
+	c.create = func(s *Server, obj map[string]interface{}) string {
+		obj["cluster_id"] = clusterID
+		return createNodeGroup(s, obj)
+	}
+	c.serve(s, w, r, segs)
+}
+
+func createCluster(s *Server, obj map[string]interface{}) string {
+	templateID, _ := obj["cluster_template_id"].(string)
+	if templateID == "" {
+		return "Invalid input for field/attribute cluster_template_id."
+	}
+
+	setDefault(obj, "node_count", 1)
+	setDefault(obj, "master_count", 1)
+	setDefault(obj, "labels", map[string]interface{}{})
+	setDefault(obj, "keypair", "")
+	setDefault(obj, "flavor_id", "")
+	setDefault(obj, "fixed_network", "")
+	setDefault(obj, "fixed_subnet", "")
+	obj["status"] = "CREATE_COMPLETE"
+	obj["status_reason"] = "Stack CREATE completed successfully"
+	obj["health_status"] = "HEALTHY"
+	obj["api_address"] = "https://" + newID()[:8] + ".kr1-k8s.nhncloud.com:6443"
+	obj["coe_version"] = "v1.28.3"
+	obj["project_id"] = s.ProjectID
+	obj["user_id"] = "mock-user-id"
+	obj["stack_id"] = newID()
+	obj["created_at"] = now()
+	obj["updated_at"] = now()
+
+	addons, _ := obj["addons"].([]interface{})
+	for _, raw := range addons {
+		if addon, ok := raw.(map[string]interface{}); ok {
+			addon["status"] = "INSTALLED"
+		}
+	}
+	obj["addons"] = addons
+	obj["api_ep_ipacl"] = map[string]interface{}{"enable": "False"}
+
+	// Magnum creates the default node groups together with the cluster.
+	obj["id"] = newID()
+	for _, ng := range []map[string]interface{}{
+		{"name": "default-master", "role": "master", "node_count": obj["master_count"]},
+		{"name": "default-worker", "role": "worker", "node_count": obj["node_count"]},
+	} {
+		ng["cluster_id"] = obj["id"]
+		ng["flavor_id"] = obj["flavor_id"]
+		ng["labels"] = obj["labels"]
+		ng["is_default"] = true
+		createNodeGroup(s, ng)
+		ng["uuid"] = s.putLocked("nodegroups", ng)
+	}
+
+	return ""
+}
+
+func updateCluster(s *Server, obj map[string]interface{}, changes map[string]interface{}) string {
+	if count, ok := changes["node_count"]; ok {
+		for _, ng := range s.store["nodegroups"] {
+			if ng["cluster_id"] == obj["id"] && ng["name"] == "default-worker" {
+				ng["node_count"] = count
+			}
+		}
+	}
+
+	obj["status"] = "UPDATE_COMPLETE"
+	obj["updated_at"] = now()
+
+	return ""
+}
+
+// clusterActions handles /clusters/{id}/actions/resize, which changes the
+// node count of a node group.
+func clusterActions(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	if body["_method"] != http.MethodPost || body["_subpath"] != "resize" {
+		return http.StatusNotFound, errorBody(http.StatusNotFound, "unknown cluster action")
+	}
+
+	ngID, _ := body["nodegroup"].(string)
+	for _, ng := range s.store["nodegroups"] {
+		if ng["cluster_id"] != obj["id"] {
+			continue
+		}
+		match := ng["name"] == "default-worker"
+		if ngID != "" {
+			match = ng["id"] == ngID || ng["name"] == ngID
+		}
+		if match {
+			ng["node_count"] = body["node_count"]
+			ng["updated_at"] = now()
+			if ng["name"] == "default-worker" {
+				obj["node_count"] = body["node_count"]
+			}
+		}
+	}
+
+	obj["status"] = "UPDATE_COMPLETE"
+	obj["updated_at"] = now()
+
+	return http.StatusAccepted, map[string]interface{}{"uuid": obj["id"]}
+}
+
+// clusterAddons handles /clusters/{id}/addons and /clusters/{id}/addons/{name}.
+func clusterAddons(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	addons, _ := obj["addons"].([]interface{})
+	name, _ := body["_subpath"].(string)
+	if name == "" {
+		switch body["_method"] {
+		case http.MethodGet:
+			return http.StatusOK, map[string]interface{}{"addons": addons}
+		case http.MethodPost:
+			name, _ = body["name"].(string)
+			if name == "" || body["version"] == nil {
+				return http.StatusBadRequest, errorBody(http.StatusBadRequest, "name and version are required")
+			}
+			if clusterAddon(addons, name) != nil {
+				return http.StatusConflict, errorBody(http.StatusConflict, fmt.Sprintf("Addon %s is already installed.", name))
+			}
+			addon := map[string]interface{}{
+				"name":    name,
+				"version": body["version"],
+				"options": body["options"],
+				"status":  "INSTALLED",
+			}
+			obj["addons"] = append(addons, addon)
+			return http.StatusAccepted, addon
+		}
+		return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only GET and POST are allowed")
+	}
+
+	addon := clusterAddon(addons, name)
+	if addon == nil {
+		return http.StatusNotFound, errorBody(http.StatusNotFound, fmt.Sprintf("Addon %s could not be found.", name))
+	}
+
+	switch body["_method"] {
+	case http.MethodGet:
+		return http.StatusOK, addon
+	case http.MethodPatch:
+		if version, ok := body["version"]; ok {
+			addon["version"] = version
+		}
+		if options, ok := body["options"]; ok {
+			addon["options"] = options
+		}
+		addon["status"] = "INSTALLED"
+		return http.StatusAccepted, addon
+	case http.MethodDelete:
+		remaining := make([]interface{}, 0, len(addons))
+		for _, raw := range addons {
+			if raw.(map[string]interface{})["name"] != name {
+				remaining = append(remaining, raw)
+			}
+		}
+		obj["addons"] = remaining
+		return http.StatusAccepted, nil
+	}
+
+	return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only GET, PATCH and DELETE are allowed")
+}
+
+func clusterAddon(addons []interface{}, name string) map[string]interface{} {
+	for _, raw := range addons {
+		if a, ok := raw.(map[string]interface{}); ok && a["name"] == name {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// addonTypes is the addon catalog returned by /addons.
+var addonTypes = []interface{}{
+	map[string]interface{}{"name": "calico", "type": "cni", "versions": []string{"v3.24.1-nks1", "v3.28.2-nks1"}, "default_version": "v3.28.2-nks1"},
+	map[string]interface{}{"name": "coredns", "type": "dns", "versions": []string{"1.8.4-nks1"}, "default_version": "1.8.4-nks1"},
+	map[string]interface{}{"name": "metrics-server", "type": "monitoring", "versions": []string{"v0.6.4-nks1", "v0.7.1-nks1"}, "default_version": "v0.7.1-nks1"},
+}
+
+func (s *Server) serveAddonTypes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET is allowed")
+		return
+	}
+	if r.URL.Query().Get("k8s_version") == "" {
+		writeError(w, http.StatusBadRequest, "k8s_version is required")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"addons": addonTypes})
+}
+
+// clusterAPIEndpointIPACL handles /clusters/{id}/api_ep_ipacl.
+func clusterAPIEndpointIPACL(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	switch body["_method"] {
+	case http.MethodGet:
+		return http.StatusOK, obj["api_ep_ipacl"]
+	case http.MethodPost:
+		ipacl := make(map[string]interface{})
+		for k, v := range body {
+			if !strings.HasPrefix(k, "_") {
+				ipacl[k] = v
+			}
+		}
+		obj["api_ep_ipacl"] = ipacl
+		obj["status"] = "UPDATE_COMPLETE"
+		obj["updated_at"] = now()
+		return http.StatusAccepted, map[string]interface{}{"uuid": obj["id"]}
+	}
+
+	return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only GET and POST are allowed")
+}
+
+func removeCluster(s *Server, obj map[string]interface{}) string {
+	for id, ng := range s.store["nodegroups"] {
+		if ng["cluster_id"] == obj["id"] {
+			delete(s.store["nodegroups"], id)
+		}
+	}
+
+	return ""
+}
+
+func createNodeGroup(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "role", "worker")
+	setDefault(obj, "node_count", 1)
+	setDefault(obj, "min_node_count", 1)
+	setDefault(obj, "max_node_count", nil)
+	setDefault(obj, "labels", map[string]interface{}{})
+	setDefault(obj, "is_default", false)
+	obj["status"] = "CREATE_COMPLETE"
+	obj["status_reason"] = ""
+	obj["project_id"] = s.ProjectID
+	obj["created_at"] = now()
+	obj["updated_at"] = now()
+
+	return ""
+}
+
+func updateNodeGroup(s *Server, obj map[string]interface{}, _ map[string]interface{}) string {
+	obj["status"] = "UPDATE_COMPLETE"
+	obj["updated_at"] = now()
+
+	return ""
+}
+
+// nodeGroupAutoscale handles /nodegroups/{id}/autoscale. NKS stores the
+// cluster autoscaler settings as ca_* labels of the node group.
+func nodeGroupAutoscale(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	if body["_method"] != http.MethodPost {
+		return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only POST is allowed")
+	}
+
+	labels := make(map[string]interface{})
+	if existing, ok := obj["labels"].(map[string]interface{}); ok {
+		for k, v := range existing {
+			labels[k] = v
+		}
+	}
+	for k, v := range body {
+		if strings.HasPrefix(k, "ca_") {
+			labels[k] = fmt.Sprint(v)
+		}
+	}
+
+	obj["labels"] = labels
+	obj["status"] = "UPDATE_COMPLETE"
+	obj["updated_at"] = now()
+
+	return http.StatusAccepted, map[string]interface{}{"uuid": obj["id"]}
+}
+
+// nodeGroupUpgrade handles /nodegroups/{id}/upgrade. Upgrading the master
+// node group upgrades the control plane, which is reported by the cluster.
+func nodeGroupUpgrade(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	if body["_method"] != http.MethodPost {
+		return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only POST is allowed")
+	}
+
+	version, _ := body["version"].(string)
+	if version == "" {
+		return http.StatusBadRequest, errorBody(http.StatusBadRequest, "version is required")
+	}
+
+	obj["version"] = version
+	obj["status"] = "UPDATE_COMPLETE"
+	obj["updated_at"] = now()
+
+	if obj["role"] == "master" {
+		if cluster, ok := s.store["clusters"][obj["cluster_id"].(string)]; ok {
+			cluster["coe_version"] = version
+			cluster["status"] = "UPDATE_COMPLETE"
+			cluster["updated_at"] = now()
+		}
+	}
+
+	return http.StatusAccepted, map[string]interface{}{"uuid": obj["id"]}
+}
+
+func createClusterTemplate(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "coe", "kubernetes")
+	setDefault(obj, "labels", map[string]interface{}{})
+	obj["project_id"] = s.ProjectID
+	obj["created_at"] = now()
+	obj["updated_at"] = now()
+
+	return ""
+}
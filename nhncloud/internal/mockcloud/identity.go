@@ -0,0 +1,166 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// catalogServices lists the service types published in the catalog together
+// with the path prefix each one is served under.
+var catalogServices = []struct {
+	Type string
+	Name string
+	Path string
+}{
+	{"identity", "keystone", "/identity/v3"},
+	{"compute", "nova", "/compute/v2.1"},
+	{"network", "neutron", "/network/"},
+	{"load-balancer", "octavia", "/load-balancer/"},
+	{"volumev2", "cinderv2", "/volume/v2/" + DefaultProjectID},
+	{"volumev3", "cinderv3", "/volume/v3/" + DefaultProjectID},
+	{"container-infra", "magnum", "/container-infra/v1"},
+	{"image", "glance", "/image/"},
+}
+
+func (s *Server) serveIdentity(w http.ResponseWriter, r *http.Request) {
+	segs := splitPath(r.URL.Path)
+
+	// /identity/v3/auth/tokens
+	if len(segs) != 4 || segs[2] != "auth" || segs[3] != "tokens" {
+		writeError(w, http.StatusNotFound, "unknown identity path")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if !s.validCredentials(body) {
+			writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+			return
+		}
+		projectID := s.scopedProject(body)
+		w.Header().Set("X-Subject-Token", s.projectToken(projectID))
+		writeJSON(w, http.StatusCreated, s.token(projectID))
+	case http.MethodGet, http.MethodHead:
+		subjectToken := r.Header.Get("X-Subject-Token")
+		projectID, ok := s.tokenProject(subjectToken)
+		if !ok {
+			writeError(w, http.StatusNotFound, "token not found")
+			return
+		}
+		w.Header().Set("X-Subject-Token", subjectToken)
+		writeJSON(w, http.StatusOK, s.token(projectID))
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not allowed")
+	}
+}
+
+// validCredentials accepts the default password credentials, any
+// application credential and the fake token itself.
+func (s *Server) validCredentials(body map[string]interface{}) bool {
+	auth, _ := body["auth"].(map[string]interface{})
+	identity, _ := auth["identity"].(map[string]interface{})
+
+	if p, ok := identity["password"].(map[string]interface{}); ok {
+		user, _ := p["user"].(map[string]interface{})
+		return user["password"] == DefaultPassword &&
+			(user["name"] == DefaultUserName || user["id"] != nil)
+	}
+
+	if _, ok := identity["application_credential"].(map[string]interface{}); ok {
+		return true
+	}
+
+	if t, ok := identity["token"].(map[string]interface{}); ok {
+		_, valid := s.tokenProject(fmt.Sprint(t["id"]))
+		return valid
+	}
+
+	return false
+}
+
+// scopedProject returns the project ID a token request is scoped to. Any
+// project is accepted, so tests can scope tokens to extra projects.
+func (s *Server) scopedProject(body map[string]interface{}) string {
+	auth, _ := body["auth"].(map[string]interface{})
+	scope, _ := auth["scope"].(map[string]interface{})
+	project, _ := scope["project"].(map[string]interface{})
+
+	if id, _ := project["id"].(string); id != "" {
+		return id
+	}
+
+	return s.ProjectID
+}
+
+// projectToken returns the token handed out for a project. The default
+// project keeps the historical token ID.
+func (s *Server) projectToken(projectID string) string {
+	if projectID == s.ProjectID {
+		return tokenID
+	}
+
+	return tokenID + "-" + projectID
+}
+
+// tokenProject returns the project a token is scoped to and whether the
+// token was issued by the server.
+func (s *Server) tokenProject(token string) (string, bool) {
+	if token == tokenID {
+		return s.ProjectID, true
+	}
+
+	if projectID := strings.TrimPrefix(token, tokenID+"-"); projectID != token && projectID != "" {
+		return projectID, true
+	}
+
+	return "", false
+}
+
+func (s *Server) token(projectID string) map[string]interface{} {
+	catalog := make([]interface{}, 0, len(catalogServices))
+	for _, svc := range catalogServices {
+		catalog = append(catalog, map[string]interface{}{
+			"id":   svc.Name,
+			"type": svc.Type,
+			"name": svc.Name,
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"id":        svc.Name + "-public",
+					"interface": "public",
+					"region":    s.Region,
+					"region_id": s.Region,
+					"url":       s.URL + svc.Path,
+				},
+			},
+		})
+	}
+
+	domain := map[string]interface{}{"id": "default", "name": "Default"}
+
+	return map[string]interface{}{
+		"token": map[string]interface{}{
+			"methods":    []string{"password"},
+			"issued_at":  time.Now().UTC().Format(time.RFC3339),
+			"expires_at": time.Now().UTC().Add(12 * time.Hour).Format(time.RFC3339),
+			"user": map[string]interface{}{
+				"id":     "mock-user-id",
+				"name":   DefaultUserName,
+				"domain": domain,
+			},
+			"project": map[string]interface{}{
+				"id":     projectID,
+				"name":   "mock-project",
+				"domain": domain,
+			},
+			"roles":   []interface{}{map[string]interface{}{"id": "member", "name": "member"}},
+			"catalog": catalog,
+		},
+	}
+}
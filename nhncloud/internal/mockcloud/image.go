@@ -0,0 +1,68 @@
+package mockcloud
+
+import (
+	"net/http"
+)
+
+// imageCollection follows the Glance v2 API, which returns unwrapped objects.
+var imageCollection = &collection{
+	kind:   "images",
+	plural: "images",
+	create: createImage,
+	render: renderImage,
+	actions: map[string]actionFunc{
+		"file": uploadImage,
+	},
+}
+
+func (s *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	// /image/v2/images/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 3 || segs[1] != "v2" || segs[2] != "images" {
+		writeError(w, http.StatusNotFound, "unknown image path")
+		return
+	}
+
+	imageCollection.serve(s, w, r, segs[3:])
+}
+
+func createImage(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "visibility", "private")
+	setDefault(obj, "min_disk", 0)
+	setDefault(obj, "min_ram", 0)
+	setDefault(obj, "protected", false)
+	setDefault(obj, "tags", []interface{}{})
+	obj["status"] = "queued"
+	obj["created_at"] = now()
+	obj["updated_at"] = now()
+
+	return ""
+}
+
+func renderImage(s *Server, obj map[string]interface{}) map[string]interface{} {
+	setDefault(obj, "min_ram", 0)
+	setDefault(obj, "protected", false)
+	setDefault(obj, "tags", []interface{}{})
+	setDefault(obj, "container_format", "bare")
+	setDefault(obj, "disk_format", "qcow2")
+	setDefault(obj, "size", 0)
+	obj["owner"] = s.ProjectID
+	obj["file"] = "/v2/images/" + obj["id"].(string) + "/file"
+	obj["self"] = "/v2/images/" + obj["id"].(string)
+	obj["schema"] = "/v2/schemas/image"
+
+	return obj
+}
+
+// uploadImage handles PUT /images/{id}/file. The data is discarded and the
+// image becomes active at once.
+func uploadImage(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	if body["_method"] != http.MethodPut {
+		return http.StatusMethodNotAllowed, errorBody(http.StatusMethodNotAllowed, "only PUT is supported")
+	}
+
+	obj["status"] = "active"
+	obj["updated_at"] = now()
+
+	return http.StatusNoContent, nil
+}
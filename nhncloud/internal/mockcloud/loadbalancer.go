@@ -0,0 +1,131 @@
+package mockcloud
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// loadBalancerCollection is shared by the Octavia endpoint and the Neutron
+// LBaaS v2 extension, so both clients see the same load balancers.
+var loadBalancerCollection = &collection{
+	kind:     "loadbalancers",
+	singular: "loadbalancer",
+	plural:   "loadbalancers",
+	create:   createLoadBalancer,
+	remove:   removeLoadBalancer,
+	render:   renderLoadBalancer,
+}
+
+// loadBalancerCollections are served under /load-balancer/v2.0.
+var loadBalancerCollections = map[string]*collection{
+	"lbaas/loadbalancers":  loadBalancerCollection,
+	"lbaas/listeners":      networkCollections["lbaas/listeners"],
+	"lbaas/pools":          networkCollections["lbaas/pools"],
+	"lbaas/healthmonitors": networkCollections["lbaas/healthmonitors"],
+}
+
+func (s *Server) serveLoadBalancer(w http.ResponseWriter, r *http.Request) {
+	// /load-balancer/v2.0/lbaas/<collection>/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 3 || segs[1] != "v2.0" {
+		writeError(w, http.StatusNotFound, "unknown load-balancer path")
+		return
+	}
+
+	serveCollections(s, w, r, loadBalancerCollections, segs[2:])
+}
+
+func createLoadBalancer(s *Server, obj map[string]interface{}) string {
+	subnetID, _ := obj["vip_subnet_id"].(string)
+	subnet, ok := s.getLocked("vpcsubnets", subnetID)
+	if !ok {
+		return fmt.Sprintf("Subnet %s could not be found", subnetID)
+	}
+
+	vipAddress, _ := obj["vip_address"].(string)
+	if vipAddress == "" {
+		vipAddress = s.allocateAddress(fmt.Sprint(subnet["cidr"]))
+	}
+
+	// Like the real service, the VIP is backed by a port on the subnet.
+	obj["id"] = newID()
+	portID := s.putLocked("ports", map[string]interface{}{
+		"name":       "loadbalancer-" + obj["id"].(string),
+		"network_id": subnet["vpc_id"],
+		"device_id":  obj["id"],
+		"fixed_ips": []interface{}{
+			map[string]interface{}{"subnet_id": subnetID, "ip_address": vipAddress},
+		},
+		"mac_address":           newMAC(),
+		"status":                "ACTIVE",
+		"admin_state_up":        true,
+		"security_groups":       []interface{}{},
+		"allowed_address_pairs": []interface{}{},
+		"tenant_id":             s.ProjectID,
+		"project_id":            s.ProjectID,
+		"tags":                  []interface{}{},
+	})
+
+	obj["vip_address"] = vipAddress
+	obj["vip_port_id"] = portID
+	obj["vip_network_id"] = subnet["vpc_id"]
+	setDefault(obj, "description", "")
+	setDefault(obj, "admin_state_up", true)
+	setDefault(obj, "provider", "amphora")
+	setDefault(obj, "loadbalancer_type", "shared")
+	setDefault(obj, "tags", []interface{}{})
+	obj["provisioning_status"] = "ACTIVE"
+	obj["operating_status"] = "ONLINE"
+	obj["tenant_id"] = s.ProjectID
+	obj["project_id"] = s.ProjectID
+
+	return ""
+}
+
+func removeLoadBalancer(s *Server, obj map[string]interface{}) string {
+	for _, listener := range s.store["listeners"] {
+		if listener["loadbalancer_id"] == obj["id"] {
+			return fmt.Sprintf("Load balancer %s is still in use by listener %s", obj["id"], listener["id"])
+		}
+	}
+
+	delete(s.store["ports"], fmt.Sprint(obj["vip_port_id"]))
+
+	return ""
+}
+
+func renderLoadBalancer(s *Server, obj map[string]interface{}) map[string]interface{} {
+	listeners := make([]interface{}, 0)
+	for _, listener := range s.listLocked("listeners") {
+		if listener["loadbalancer_id"] == obj["id"] {
+			listeners = append(listeners, map[string]interface{}{"id": listener["id"]})
+		}
+	}
+	obj["listeners"] = listeners
+
+	pools := make([]interface{}, 0)
+	for _, pool := range s.listLocked("pools") {
+		if pool["loadbalancer_id"] == obj["id"] {
+			pools = append(pools, map[string]interface{}{"id": pool["id"]})
+		}
+	}
+	obj["pools"] = pools
+
+	return obj
+}
+
+// createLBChild settles listeners, pools and health monitors immediately.
+func createLBChild(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "admin_state_up", true)
+	setDefault(obj, "tags", []interface{}{})
+	obj["provisioning_status"] = "ACTIVE"
+	obj["operating_status"] = "ONLINE"
+	obj["tenant_id"] = s.ProjectID
+	obj["project_id"] = s.ProjectID
+
+	if lbID, ok := obj["loadbalancer_id"]; ok {
+		obj["loadbalancers"] = []interface{}{map[string]interface{}{"id": lbID}}
+	}
+
+	return ""
+}
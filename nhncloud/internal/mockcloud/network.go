@@ -0,0 +1,978 @@
+package mockcloud
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// networkCollections are served under /network/v2.0. Neutron networks and
+// subnets are views over the NHN Cloud VPC objects, as in the real API.
+var networkCollections = map[string]*collection{
+	"vpcs": {
+		kind:     "vpcs",
+		singular: "vpc",
+		plural:   "vpcs",
+		create:   createVPC,
+		remove:   removeVPC,
+		render:   renderVPC,
+	},
+	"networks": {
+		kind:     "vpcs",
+		singular: "network",
+		plural:   "networks",
+		create:   createVPC,
+		remove:   removeVPC,
+		render:   renderNetwork,
+	},
+	"vpcsubnets": {
+		kind:     "vpcsubnets",
+		singular: "vpcsubnet",
+		plural:   "vpcsubnets",
+		create:   createVPCSubnet,
+		remove:   removeVPCSubnet,
+		render:   renderVPCSubnet,
+		actions: map[string]actionFunc{
+			"attach_routingtable": attachVPCSubnetRoutingtable,
+			"detach_routingtable": detachVPCSubnetRoutingtable,
+		},
+	},
+	"subnets": {
+		kind:     "vpcsubnets",
+		singular: "subnet",
+		plural:   "subnets",
+		create:   createVPCSubnet,
+		render:   renderSubnet,
+	},
+	"routingtables": {
+		kind:     "routingtables",
+		singular: "routingtable",
+		plural:   "routingtables",
+		create:   createRoutingtable,
+		remove:   removeRoutingtable,
+		render:   renderRoutingtable,
+		actions: map[string]actionFunc{
+			"attach_gateway": attachRoutingtableGateway,
+			"detach_gateway": detachRoutingtableGateway,
+			"set_as_default": setRoutingtableAsDefault,
+		},
+	},
+	"routes": {
+		kind:     "routes",
+		singular: "route",
+		plural:   "routes",
+		create:   createRoute,
+	},
+	"peerings": {
+		kind:     "peerings",
+		singular: "peering",
+		plural:   "peerings",
+		create:   createPeering,
+		remove:   removePeering,
+		render:   renderPeering,
+	},
+	"internetgateways": {
+		kind:     "internetgateways",
+		singular: "internetgateway",
+		plural:   "internetgateways",
+		create:   createInternetGateway,
+		remove:   removeInternetGateway,
+	},
+	"natgateways": {
+		kind:     "natgateways",
+		singular: "natgateway",
+		plural:   "natgateways",
+		create:   createNATGateway,
+	},
+	"servicegateways": {
+		kind:     "servicegateways",
+		singular: "servicegateway",
+		plural:   "servicegateways",
+		create:   createServiceGateway,
+	},
+	"acls": {
+		kind:     "acls",
+		singular: "acl",
+		plural:   "acls",
+		create:   createACL,
+		remove:   removeACL,
+	},
+	"acl_rules": {
+		kind:     "acl_rules",
+		singular: "acl_rule",
+		plural:   "acl_rules",
+		create:   createACLRule,
+		update:   updateACLRule,
+	},
+	"aclbindings": {
+		kind:     "aclbindings",
+		singular: "aclbinding",
+		plural:   "aclbindings",
+		create:   createACLBinding,
+	},
+	"ports": {
+		kind:     "ports",
+		singular: "port",
+		plural:   "ports",
+		create:   createPort,
+	},
+	"security-groups": {
+		kind:     "security-groups",
+		singular: "security_group",
+		plural:   "security_groups",
+		create:   createSecurityGroup,
+		remove:   removeSecurityGroup,
+		render:   renderSecurityGroup,
+	},
+	"security-group-rules": {
+		kind:     "security-group-rules",
+		singular: "security_group_rule",
+		plural:   "security_group_rules",
+		create:   createSecurityGroupRule,
+	},
+	"lbaas/loadbalancers": loadBalancerCollection,
+	"lbaas/listeners": {
+		kind:     "listeners",
+		singular: "listener",
+		plural:   "listeners",
+		create:   createLBChild,
+	},
+	"lbaas/pools": {
+		kind:     "pools",
+		singular: "pool",
+		plural:   "pools",
+		create:   createLBChild,
+	},
+	"lbaas/healthmonitors": {
+		kind:     "healthmonitors",
+		singular: "healthmonitor",
+		plural:   "healthmonitors",
+		create:   createLBChild,
+	},
+}
+
+func (s *Server) serveNetwork(w http.ResponseWriter, r *http.Request) {
+	// /network/v2.0/<collection>/...
+	segs := splitPath(r.URL.Path)
+	if len(segs) < 3 || segs[1] != "v2.0" {
+		writeError(w, http.StatusNotFound, "unknown network path")
+		return
+	}
+
+	serveCollections(s, w, r, networkCollections, segs[2:])
+}
+
+// serveCollections finds the collection addressed by segs, allowing
+// two-segment names such as "lbaas/loadbalancers", and serves the request.
+func serveCollections(s *Server, w http.ResponseWriter, r *http.Request, collections map[string]*collection, segs []string) {
+	if len(segs) > 1 {
+		if c, ok := collections[segs[0]+"/"+segs[1]]; ok {
+			c.serve(s, w, r, segs[2:])
+			return
+		}
+	}
+
+	if c, ok := collections[segs[0]]; ok {
+		c.serve(s, w, r, segs[1:])
+		return
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("unknown collection %q", strings.Join(segs, "/")))
+}
+
+func createVPC(s *Server, obj map[string]interface{}) string {
+	cidr, _ := obj["cidrv4"].(string)
+	if cidr != "" {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Sprintf("Invalid input for cidrv4: %s", err)
+		}
+	}
+
+	setDefault(obj, "state", "available")
+	setDefault(obj, "external", false)
+	setDefault(obj, "shared", false)
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	// Every VPC comes with a default routing table.
+	obj["id"] = newID()
+	s.putLocked("routingtables", map[string]interface{}{
+		"name":          "default_rt",
+		"vpc_id":        obj["id"],
+		"default_table": true,
+		"distributed":   true,
+		"gateway_id":    "",
+		"state":         "available",
+		"tenant_id":     obj["tenant_id"],
+		"create_time":   now(),
+	})
+
+	return ""
+}
+
+func removeVPC(s *Server, obj map[string]interface{}) string {
+	for _, subnet := range s.store["vpcsubnets"] {
+		if subnet["vpc_id"] == obj["id"] {
+			return fmt.Sprintf("VPC %s is still in use by subnet %s", obj["id"], subnet["id"])
+		}
+	}
+
+	for _, peering := range s.store["peerings"] {
+		if peering["local_vpc_id"] == obj["id"] || peering["remote_vpc_id"] == obj["id"] {
+			return fmt.Sprintf("VPC %s is still in use by peering %s", obj["id"], peering["id"])
+		}
+	}
+
+	for _, kind := range []string{"natgateways", "servicegateways"} {
+		for _, gateway := range s.store[kind] {
+			if gateway["vpc_id"] == obj["id"] {
+				return fmt.Sprintf("VPC %s is still in use by gateway %s", obj["id"], gateway["id"])
+			}
+		}
+	}
+
+	for id, rt := range s.store["routingtables"] {
+		if rt["vpc_id"] == obj["id"] {
+			delete(s.store["routingtables"], id)
+		}
+	}
+
+	return ""
+}
+
+func renderVPC(s *Server, obj map[string]interface{}) map[string]interface{} {
+	subnets := make([]interface{}, 0)
+	for _, subnet := range s.listLocked("vpcsubnets") {
+		if subnet["vpc_id"] == obj["id"] {
+			subnets = append(subnets, renderVPCSubnet(s, subnet))
+		}
+	}
+
+	routingtables := make([]interface{}, 0)
+	for _, rt := range s.listLocked("routingtables") {
+		if rt["vpc_id"] == obj["id"] {
+			routingtables = append(routingtables, map[string]interface{}{
+				"id":            rt["id"],
+				"name":          rt["name"],
+				"default_table": rt["default_table"],
+				"distributed":   rt["distributed"],
+				"gateway_id":    rt["gateway_id"],
+			})
+		}
+	}
+
+	obj["subnets"] = subnets
+	obj["routingtables"] = routingtables
+
+	return obj
+}
+
+func renderNetwork(s *Server, obj map[string]interface{}) map[string]interface{} {
+	subnetIDs := make([]interface{}, 0)
+	for _, subnet := range s.listLocked("vpcsubnets") {
+		if subnet["vpc_id"] == obj["id"] {
+			subnetIDs = append(subnetIDs, subnet["id"])
+		}
+	}
+
+	return map[string]interface{}{
+		"id":              obj["id"],
+		"name":            obj["name"],
+		"status":          "ACTIVE",
+		"admin_state_up":  true,
+		"shared":          obj["shared"],
+		"router:external": obj["external"],
+		"tenant_id":       obj["tenant_id"],
+		"project_id":      obj["tenant_id"],
+		"subnets":         subnetIDs,
+		"tags":            []interface{}{},
+	}
+}
+
+func createVPCSubnet(s *Server, obj map[string]interface{}) string {
+	// Neutron-style requests carry network_id instead of vpc_id.
+	if v, ok := obj["network_id"]; ok {
+		setDefault(obj, "vpc_id", v)
+	}
+
+	vpcID, _ := obj["vpc_id"].(string)
+	vpc, ok := s.getLocked("vpcs", vpcID)
+	if !ok {
+		return fmt.Sprintf("VPC %s could not be found", vpcID)
+	}
+
+	cidr, _ := obj["cidr"].(string)
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Sprintf("Invalid input for cidr: %s", err)
+	}
+
+	if vpcCIDR, _ := vpc["cidrv4"].(string); vpcCIDR != "" {
+		if _, vpcNet, err := net.ParseCIDR(vpcCIDR); err == nil && !cidrContains(vpcNet, subnet) {
+			return fmt.Sprintf("Subnet %s is not within VPC range %s", cidr, vpcCIDR)
+		}
+	}
+
+	for _, sibling := range s.store["vpcsubnets"] {
+		if sibling["vpc_id"] != vpcID {
+			continue
+		}
+		if _, other, err := net.ParseCIDR(fmt.Sprint(sibling["cidr"])); err == nil && cidrOverlaps(subnet, other) {
+			return fmt.Sprintf("Subnet %s overlaps with subnet %s", cidr, sibling["id"])
+		}
+	}
+
+	if _, ok := obj["routingtable_id"]; !ok {
+		for _, rt := range s.store["routingtables"] {
+			if rt["vpc_id"] == vpcID && rt["default_table"] == true {
+				obj["routingtable_id"] = rt["id"]
+			}
+		}
+	}
+
+	ones, bits := subnet.Mask.Size()
+	setDefault(obj, "gateway", nthAddress(subnet, 1))
+	setDefault(obj, "available_ip_count", (1<<uint(bits-ones))-5)
+	setDefault(obj, "state", "available")
+	setDefault(obj, "shared", false)
+	setDefault(obj, "external", false)
+	setDefault(obj, "routingtable_explicit", false)
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+func renderVPCSubnet(s *Server, obj map[string]interface{}) map[string]interface{} {
+	if vpc, ok := s.getLocked("vpcs", fmt.Sprint(obj["vpc_id"])); ok {
+		obj["vpc"] = map[string]interface{}{
+			"id":     vpc["id"],
+			"name":   vpc["name"],
+			"state":  vpc["state"],
+			"cidrv4": vpc["cidrv4"],
+			"shared": vpc["shared"],
+		}
+	}
+
+	if rt, ok := s.getLocked("routingtables", fmt.Sprint(obj["routingtable_id"])); ok {
+		obj["routingtable"] = map[string]interface{}{
+			"id":            rt["id"],
+			"name":          rt["name"],
+			"default_table": rt["default_table"],
+			"explicit":      obj["routingtable_explicit"],
+			"gateway_id":    rt["gateway_id"],
+		}
+	}
+
+	setDefault(obj, "routes", []interface{}{})
+
+	return obj
+}
+
+func renderSubnet(s *Server, obj map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":          obj["id"],
+		"name":        obj["name"],
+		"network_id":  obj["vpc_id"],
+		"cidr":        obj["cidr"],
+		"gateway_ip":  obj["gateway"],
+		"ip_version":  4,
+		"enable_dhcp": true,
+		"tenant_id":   obj["tenant_id"],
+		"project_id":  obj["tenant_id"],
+		"tags":        []interface{}{},
+	}
+}
+
+func attachVPCSubnetRoutingtable(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	rtID, _ := body["routingtable_id"].(string)
+	if _, ok := s.getLocked("routingtables", rtID); !ok {
+		return http.StatusNotFound, errorBody(http.StatusNotFound, "routingtable "+rtID+" could not be found")
+	}
+
+	obj["routingtable_id"] = rtID
+	obj["routingtable_explicit"] = true
+
+	return http.StatusOK, map[string]interface{}{"vpcsubnet": renderVPCSubnet(s, copyObject(obj))}
+}
+
+func detachVPCSubnetRoutingtable(s *Server, obj map[string]interface{}, _ map[string]interface{}) (int, interface{}) {
+	for _, rt := range s.store["routingtables"] {
+		if rt["vpc_id"] == obj["vpc_id"] && rt["default_table"] == true {
+			obj["routingtable_id"] = rt["id"]
+		}
+	}
+	obj["routingtable_explicit"] = false
+
+	return http.StatusOK, map[string]interface{}{"vpcsubnet": renderVPCSubnet(s, copyObject(obj))}
+}
+
+func createRoutingtable(s *Server, obj map[string]interface{}) string {
+	vpcID, _ := obj["vpc_id"].(string)
+	if _, ok := s.getLocked("vpcs", vpcID); !ok {
+		return fmt.Sprintf("VPC %s could not be found", vpcID)
+	}
+
+	setDefault(obj, "default_table", false)
+	setDefault(obj, "distributed", true)
+	setDefault(obj, "gateway_id", "")
+	setDefault(obj, "state", "available")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+func removeRoutingtable(s *Server, obj map[string]interface{}) string {
+	if obj["default_table"] == true {
+		return fmt.Sprintf("Routingtable %s is the default table of its VPC", obj["id"])
+	}
+
+	for _, subnet := range s.store["vpcsubnets"] {
+		if subnet["routingtable_id"] == obj["id"] {
+			return fmt.Sprintf("Routingtable %s is still in use by subnet %s", obj["id"], subnet["id"])
+		}
+	}
+
+	// The routes of a routing table are deleted with it.
+	for id, route := range s.store["routes"] {
+		if route["routingtable_id"] == obj["id"] {
+			delete(s.store["routes"], id)
+		}
+	}
+
+	return ""
+}
+
+// createRoute validates a static route. A route targets either an IP
+// address in the VPC with gateway, or a gateway by its ID with gateway_id.
+func createRoute(s *Server, obj map[string]interface{}) string {
+	rtID, _ := obj["routingtable_id"].(string)
+	if _, ok := s.getLocked("routingtables", rtID); !ok {
+		return fmt.Sprintf("Routingtable %s could not be found", rtID)
+	}
+
+	cidr, _ := obj["cidr"].(string)
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return fmt.Sprintf("Invalid CIDR %q", cidr)
+	}
+
+	gateway, _ := obj["gateway"].(string)
+	gatewayID, _ := obj["gateway_id"].(string)
+	if (gateway == "") == (gatewayID == "") {
+		return "Exactly one of gateway and gateway_id must be given"
+	}
+
+	for _, route := range s.store["routes"] {
+		if route["routingtable_id"] == rtID && route["cidr"] == ipNet.String() {
+			return fmt.Sprintf("Route for %s already exists in routingtable %s", cidr, rtID)
+		}
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	obj["cidr"] = ipNet.String()
+	obj["mask"] = ones
+	setDefault(obj, "gateway", "")
+	setDefault(obj, "gateway_id", "")
+	setDefault(obj, "tenant_id", s.ProjectID)
+
+	return ""
+}
+
+func renderRoutingtable(s *Server, obj map[string]interface{}) map[string]interface{} {
+	vpcs := make([]interface{}, 0)
+	if vpc, ok := s.getLocked("vpcs", fmt.Sprint(obj["vpc_id"])); ok {
+		vpcs = append(vpcs, map[string]interface{}{"id": vpc["id"], "name": vpc["name"]})
+	}
+
+	subnets := make([]interface{}, 0)
+	for _, subnet := range s.listLocked("vpcsubnets") {
+		if subnet["routingtable_id"] == obj["id"] {
+			subnets = append(subnets, map[string]interface{}{"id": subnet["id"], "name": subnet["name"]})
+		}
+	}
+
+	routes := make([]interface{}, 0)
+	for _, route := range s.listLocked("routes") {
+		if route["routingtable_id"] == obj["id"] {
+			routes = append(routes, route)
+		}
+	}
+
+	obj["vpcs"] = vpcs
+	obj["subnets"] = subnets
+	obj["routes"] = routes
+
+	return obj
+}
+
+func attachRoutingtableGateway(s *Server, obj map[string]interface{}, body map[string]interface{}) (int, interface{}) {
+	obj["gateway_id"] = body["gateway_id"]
+
+	if gateway, ok := s.getLocked("internetgateways", fmt.Sprint(body["gateway_id"])); ok {
+		gateway["routingtable_id"] = obj["id"]
+		gateway["state"] = "available"
+	}
+
+	return http.StatusOK, map[string]interface{}{"routingtable": renderRoutingtable(s, copyObject(obj))}
+}
+
+func detachRoutingtableGateway(s *Server, obj map[string]interface{}, _ map[string]interface{}) (int, interface{}) {
+	if gateway, ok := s.getLocked("internetgateways", fmt.Sprint(obj["gateway_id"])); ok {
+		gateway["routingtable_id"] = ""
+		gateway["state"] = "unavailable"
+	}
+
+	obj["gateway_id"] = ""
+
+	return http.StatusOK, map[string]interface{}{"routingtable": renderRoutingtable(s, copyObject(obj))}
+}
+
+func setRoutingtableAsDefault(s *Server, obj map[string]interface{}, _ map[string]interface{}) (int, interface{}) {
+	for _, rt := range s.store["routingtables"] {
+		if rt["vpc_id"] == obj["vpc_id"] {
+			rt["default_table"] = false
+		}
+	}
+	obj["default_table"] = true
+
+	return http.StatusOK, map[string]interface{}{"routingtable": renderRoutingtable(s, copyObject(obj))}
+}
+
+func createPort(s *Server, obj map[string]interface{}) string {
+	networkID, _ := obj["network_id"].(string)
+
+	if _, ok := obj["fixed_ips"]; !ok {
+		for _, subnet := range s.listLocked("vpcsubnets") {
+			if subnet["vpc_id"] == networkID {
+				obj["fixed_ips"] = []interface{}{
+					map[string]interface{}{
+						"subnet_id":  subnet["id"],
+						"ip_address": s.allocateAddress(fmt.Sprint(subnet["cidr"])),
+					},
+				}
+				break
+			}
+		}
+	}
+
+	setDefault(obj, "fixed_ips", []interface{}{})
+	setDefault(obj, "mac_address", newMAC())
+	setDefault(obj, "status", "ACTIVE")
+	setDefault(obj, "admin_state_up", true)
+	setDefault(obj, "security_groups", []interface{}{})
+	setDefault(obj, "allowed_address_pairs", []interface{}{})
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "project_id", s.ProjectID)
+	setDefault(obj, "tags", []interface{}{})
+
+	return ""
+}
+
+func createSecurityGroup(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "description", "")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "project_id", s.ProjectID)
+	setDefault(obj, "tags", []interface{}{})
+
+	return ""
+}
+
+func removeSecurityGroup(s *Server, obj map[string]interface{}) string {
+	for id, rule := range s.store["security-group-rules"] {
+		if rule["security_group_id"] == obj["id"] {
+			delete(s.store["security-group-rules"], id)
+		}
+	}
+
+	return ""
+}
+
+func renderSecurityGroup(s *Server, obj map[string]interface{}) map[string]interface{} {
+	rules := make([]interface{}, 0)
+	for _, rule := range s.listLocked("security-group-rules") {
+		if rule["security_group_id"] == obj["id"] {
+			rules = append(rules, rule)
+		}
+	}
+	obj["security_group_rules"] = rules
+
+	return obj
+}
+
+func createSecurityGroupRule(s *Server, obj map[string]interface{}) string {
+	sgID, _ := obj["security_group_id"].(string)
+	if _, ok := s.getLocked("security-groups", sgID); !ok {
+		return fmt.Sprintf("Security group %s could not be found", sgID)
+	}
+
+	setDefault(obj, "ethertype", "IPv4")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "project_id", s.ProjectID)
+
+	return ""
+}
+
+// allocateAddress returns the next unused host address of cidr, skipping the
+// network, gateway and the few addresses NHN Cloud reserves.
+func (s *Server) allocateAddress(cidr string) string {
+	_, subnet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return ""
+	}
+
+	used := make(map[string]bool)
+	for _, port := range s.store["ports"] {
+		ips, _ := port["fixed_ips"].([]interface{})
+		for _, ip := range ips {
+			if m, ok := ip.(map[string]interface{}); ok {
+				used[fmt.Sprint(m["ip_address"])] = true
+			}
+		}
+	}
+
+	for i := 10; ; i++ {
+		addr := nthAddress(subnet, i)
+		if addr == "" {
+			return ""
+		}
+		if !used[addr] {
+			return addr
+		}
+	}
+}
+
+func nthAddress(n *net.IPNet, i int) string {
+	ip := n.IP.To4()
+	if ip == nil {
+		return ""
+	}
+
+	v := binary.BigEndian.Uint32(ip) + uint32(i)
+	out := make(net.IP, 4)
+	binary.BigEndian.PutUint32(out, v)
+	if !n.Contains(out) {
+		return ""
+	}
+
+	return out.String()
+}
+
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+
+	return innerOnes >= outerOnes && outer.Contains(inner.IP)
+}
+
+func cidrOverlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func newMAC() string {
+	id := newID()
+	return "fa:16:3e:" + id[0:2] + ":" + id[2:4] + ":" + id[4:6]
+}
+
+func hasKey(obj map[string]interface{}, key string) bool {
+	_, ok := obj[key]
+	return ok
+}
+
+func setDefault(obj map[string]interface{}, key string, value interface{}) {
+	if _, ok := obj[key]; !ok {
+		obj[key] = value
+	}
+}
+
+func errorBody(code int, msg string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": msg,
+		},
+	}
+}
+
+// createPeering connects two VPCs of the project. The peering is active at
+// once, and the CIDRs of the VPCs must not overlap.
+func createPeering(s *Server, obj map[string]interface{}) string {
+	localID, _ := obj["local_vpc_id"].(string)
+	remoteID, _ := obj["remote_vpc_id"].(string)
+
+	local, ok := s.getLocked("vpcs", localID)
+	if !ok {
+		return fmt.Sprintf("VPC %s could not be found", localID)
+	}
+
+	remote, ok := s.getLocked("vpcs", remoteID)
+	if !ok {
+		return fmt.Sprintf("VPC %s could not be found", remoteID)
+	}
+
+	if localID == remoteID {
+		return "A VPC cannot be peered with itself"
+	}
+
+	_, localNet, errLocal := net.ParseCIDR(fmt.Sprint(local["cidrv4"]))
+	_, remoteNet, errRemote := net.ParseCIDR(fmt.Sprint(remote["cidrv4"]))
+	if errLocal == nil && errRemote == nil && cidrOverlaps(localNet, remoteNet) {
+		return fmt.Sprintf("The CIDR of VPC %s overlaps with the CIDR of VPC %s", localID, remoteID)
+	}
+
+	for _, peering := range s.store["peerings"] {
+		if (peering["local_vpc_id"] == localID && peering["remote_vpc_id"] == remoteID) ||
+			(peering["local_vpc_id"] == remoteID && peering["remote_vpc_id"] == localID) {
+			return fmt.Sprintf("VPC %s is already peered with VPC %s by peering %s", localID, remoteID, peering["id"])
+		}
+	}
+
+	setDefault(obj, "name", "")
+	setDefault(obj, "state", "ACTIVE")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+func removePeering(s *Server, obj map[string]interface{}) string {
+	for _, route := range s.store["routes"] {
+		if route["gateway_id"] == obj["id"] {
+			return fmt.Sprintf("Peering %s is still in use by route %s", obj["id"], route["id"])
+		}
+	}
+
+	return ""
+}
+
+func renderPeering(s *Server, obj map[string]interface{}) map[string]interface{} {
+	for _, side := range []string{"local", "remote"} {
+		if vpc, ok := s.getLocked("vpcs", fmt.Sprint(obj[side+"_vpc_id"])); ok {
+			obj[side+"_vpc"] = map[string]interface{}{
+				"id":     vpc["id"],
+				"name":   vpc["name"],
+				"cidrv4": vpc["cidrv4"],
+			}
+		}
+	}
+
+	return obj
+}
+
+// createInternetGateway connects a project to an external network. The
+// gateway is unavailable until it is attached to a routing table.
+func createInternetGateway(s *Server, obj map[string]interface{}) string {
+	networkID, _ := obj["external_network_id"].(string)
+
+	network, ok := s.getLocked("vpcs", networkID)
+	if !ok {
+		return fmt.Sprintf("External network %s could not be found", networkID)
+	}
+	if network["external"] != true {
+		return fmt.Sprintf("Network %s is not an external network", networkID)
+	}
+
+	setDefault(obj, "name", "")
+	setDefault(obj, "routingtable_id", "")
+	setDefault(obj, "state", "unavailable")
+	setDefault(obj, "migrate_status", "none")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+func removeInternetGateway(s *Server, obj map[string]interface{}) string {
+	for _, rt := range s.store["routingtables"] {
+		if rt["gateway_id"] == obj["id"] {
+			return fmt.Sprintf("Internet gateway %s is still attached to routing table %s", obj["id"], rt["id"])
+		}
+	}
+
+	return ""
+}
+
+// createNATGateway places a NAT gateway in a subnet of a VPC. The gateway is
+// active at once.
+func createNATGateway(s *Server, obj map[string]interface{}) string {
+	if msg := checkGatewaySubnet(s, obj); msg != "" {
+		return msg
+	}
+
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "floatingip_id", "")
+	setDefault(obj, "state", "ACTIVE")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+// createServiceGateway places a gateway to an NHN Cloud service endpoint in
+// a subnet of a VPC. It takes the fourth address of the subnet unless one is
+// given.
+func createServiceGateway(s *Server, obj map[string]interface{}) string {
+	if msg := checkGatewaySubnet(s, obj); msg != "" {
+		return msg
+	}
+
+	if endpointID, _ := obj["service_endpoint_id"].(string); endpointID == "" {
+		return "service_endpoint_id is required"
+	}
+
+	subnet, _ := s.getLocked("vpcsubnets", fmt.Sprint(obj["subnet_id"]))
+	if _, n, err := net.ParseCIDR(fmt.Sprint(subnet["cidr"])); err == nil {
+		setDefault(obj, "ip_address", nthAddress(n, 4))
+	}
+
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "state", "ACTIVE")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+// checkGatewaySubnet verifies that the VPC and the subnet of a gateway exist
+// and that the subnet belongs to the VPC.
+func checkGatewaySubnet(s *Server, obj map[string]interface{}) string {
+	vpcID, _ := obj["vpc_id"].(string)
+	subnetID, _ := obj["subnet_id"].(string)
+
+	if _, ok := s.getLocked("vpcs", vpcID); !ok {
+		return fmt.Sprintf("VPC %s could not be found", vpcID)
+	}
+
+	subnet, ok := s.getLocked("vpcsubnets", subnetID)
+	if !ok {
+		return fmt.Sprintf("Subnet %s could not be found", subnetID)
+	}
+	if subnet["vpc_id"] != vpcID {
+		return fmt.Sprintf("Subnet %s does not belong to VPC %s", subnetID, vpcID)
+	}
+
+	return ""
+}
+
+func removeVPCSubnet(s *Server, obj map[string]interface{}) string {
+	for _, binding := range s.store["aclbindings"] {
+		if binding["subnet_id"] == obj["id"] {
+			return fmt.Sprintf("Subnet %s is still bound to ACL %s", obj["id"], binding["acl_id"])
+		}
+	}
+
+	for _, kind := range []string{"natgateways", "servicegateways"} {
+		for _, gateway := range s.store[kind] {
+			if gateway["subnet_id"] == obj["id"] {
+				return fmt.Sprintf("Subnet %s is still in use by gateway %s", obj["id"], gateway["id"])
+			}
+		}
+	}
+
+	return ""
+}
+
+func createACL(s *Server, obj map[string]interface{}) string {
+	setDefault(obj, "name", "")
+	setDefault(obj, "description", "")
+	setDefault(obj, "tenant_id", s.ProjectID)
+	setDefault(obj, "create_time", now())
+
+	return ""
+}
+
+// removeACL deletes the rules of an ACL with it. An ACL bound to a subnet
+// cannot be deleted.
+func removeACL(s *Server, obj map[string]interface{}) string {
+	for _, binding := range s.store["aclbindings"] {
+		if binding["acl_id"] == obj["id"] {
+			return fmt.Sprintf("ACL %s is still bound to subnet %s", obj["id"], binding["subnet_id"])
+		}
+	}
+
+	for id, rule := range s.store["acl_rules"] {
+		if rule["acl_id"] == obj["id"] {
+			delete(s.store["acl_rules"], id)
+		}
+	}
+
+	return ""
+}
+
+func createACLRule(s *Server, obj map[string]interface{}) string {
+	aclID, _ := obj["acl_id"].(string)
+	if _, ok := s.getLocked("acls", aclID); !ok {
+		return fmt.Sprintf("ACL %s could not be found", aclID)
+	}
+
+	setDefault(obj, "description", "")
+	setDefault(obj, "protocol", nil)
+	setDefault(obj, "ethertype", "IPv4")
+	setDefault(obj, "src_ip", "0.0.0.0/0")
+	setDefault(obj, "dst_ip", "0.0.0.0/0")
+	for _, key := range []string{"src_port_range_min", "src_port_range_max", "dst_port_range_min", "dst_port_range_max"} {
+		setDefault(obj, key, nil)
+	}
+	setDefault(obj, "tenant_id", s.ProjectID)
+
+	return validateACLRule(s, obj)
+}
+
+func updateACLRule(s *Server, obj map[string]interface{}, _ map[string]interface{}) string {
+	return validateACLRule(s, obj)
+}
+
+// validateACLRule checks the policy, the order and the port ranges of a
+// rule. No two rules of an ACL may have the same order.
+func validateACLRule(s *Server, obj map[string]interface{}) string {
+	if policy := obj["policy"]; policy != "allow" && policy != "deny" {
+		return fmt.Sprintf("Invalid policy %v", policy)
+	}
+
+	if _, ok := obj["order_num"].(float64); !ok {
+		return "order_num is required"
+	}
+
+	for id, rule := range s.store["acl_rules"] {
+		if id != obj["id"] && rule["acl_id"] == obj["acl_id"] && fmt.Sprint(rule["order_num"]) == fmt.Sprint(obj["order_num"]) {
+			return fmt.Sprintf("ACL rule %s of ACL %s already has order %v", id, obj["acl_id"], obj["order_num"])
+		}
+	}
+
+	for _, side := range []string{"src", "dst"} {
+		min, okMin := obj[side+"_port_range_min"].(float64)
+		max, okMax := obj[side+"_port_range_max"].(float64)
+		if okMin && okMax && min > max {
+			return fmt.Sprintf("Invalid %s port range %v-%v", side, min, max)
+		}
+	}
+
+	return ""
+}
+
+func createACLBinding(s *Server, obj map[string]interface{}) string {
+	aclID, _ := obj["acl_id"].(string)
+	if _, ok := s.getLocked("acls", aclID); !ok {
+		return fmt.Sprintf("ACL %s could not be found", aclID)
+	}
+
+	subnetID, _ := obj["subnet_id"].(string)
+	if _, ok := s.getLocked("vpcsubnets", subnetID); !ok {
+		return fmt.Sprintf("Subnet %s could not be found", subnetID)
+	}
+
+	for _, binding := range s.store["aclbindings"] {
+		if binding["subnet_id"] == subnetID {
+			return fmt.Sprintf("Subnet %s is already bound to ACL %s", subnetID, binding["acl_id"])
+		}
+	}
+
+	setDefault(obj, "tenant_id", s.ProjectID)
+
+	return ""
+}
@@ -0,0 +1,380 @@
+// Package mockcloud provides an in-process fake of the NHN Cloud APIs used
+// by the provider, so resources can be exercised with resource.UnitTest
+// without network access or a real tenant.
+//
+// The server speaks just enough Keystone v3 to hand out a token and a service
+// catalog, and keeps the state of every other service in memory. Objects are
+// stored as plain JSON maps, so the fake does not depend on the SDK types and
+// is lenient about fields it does not know.
+package mockcloud
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRegion is the region every catalog endpoint is registered in.
+	DefaultRegion = "KR1"
+
+	// DefaultProjectID is the project the fake token is scoped to.
+	DefaultProjectID = "8c4b4c2d2ff04b3e9a8a2f3b8a8d1c11"
+
+	// DefaultUserName and DefaultPassword are the only accepted credentials.
+	DefaultUserName = "mock-user@example.com"
+	DefaultPassword = "mock-password"
+
+	// DefaultNetworkName is the name of the pre-seeded VPC.
+	DefaultNetworkName = "Default Network"
+
+	// DefaultFlavorName is the name of the pre-seeded flavor.
+	DefaultFlavorName = "m2.c1m2"
+
+	// DefaultImageName is the name of the pre-seeded image.
+	DefaultImageName = "Ubuntu Server 22.04 LTS"
+
+	tokenID = "mock-token"
+)
+
+// Server is a fake NHN Cloud endpoint backed by in-memory state.
+type Server struct {
+	*httptest.Server
+
+	Region    string
+	ProjectID string
+
+	mu       sync.Mutex
+	store    map[string]map[string]map[string]interface{}
+	services map[string]http.HandlerFunc
+	requests []string
+}
+
+// NewServer starts a fake NHN Cloud server seeded with a default VPC,
+// subnet, routing table, security group, flavor and image. The caller must
+// call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		Region:    DefaultRegion,
+		ProjectID: DefaultProjectID,
+		store:     make(map[string]map[string]map[string]interface{}),
+	}
+
+	s.services = map[string]http.HandlerFunc{
+		"identity":        s.serveIdentity,
+		"compute":         s.serveCompute,
+		"network":         s.serveNetwork,
+		"load-balancer":   s.serveLoadBalancer,
+		"volume":          s.serveBlockStorage,
+		"container-infra": s.serveContainerInfra,
+		"image":           s.serveImage,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.seed()
+
+	return s
+}
+
+// AuthURL returns the Keystone v3 endpoint to use as the provider auth_url.
+func (s *Server) AuthURL() string {
+	return s.URL + "/identity/v3"
+}
+
+// Get returns a copy of the stored object of the given kind, for example
+// "servers" or "vpcs".
+func (s *Server) Get(kind, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.store[kind][id]
+	if !ok {
+		return nil, false
+	}
+
+	return copyObject(obj), true
+}
+
+// List returns copies of all stored objects of the given kind, sorted by ID.
+func (s *Server) List(kind string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.listLocked(kind)
+}
+
+// Put stores obj under the given kind, generating an ID if it has none, and
+// returns the ID. It is intended for seeding state from tests.
+func (s *Server) Put(kind string, obj map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.putLocked(kind, obj)
+}
+
+// Update merges fields into a stored object, for example to simulate drift
+// or an out-of-band status change. It reports whether the object exists.
+func (s *Server) Update(kind, id string, fields map[string]interface{}) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.store[kind][id]
+	if !ok {
+		return false
+	}
+	for k, v := range fields {
+		obj[k] = v
+	}
+
+	return true
+}
+
+// Delete removes a stored object, for example to simulate a resource that
+// was deleted outside of Terraform.
+func (s *Server) Delete(kind, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.store[kind], id)
+}
+
+// Requests returns the "METHOD /path" lines of every request received so far.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	s.mu.Unlock()
+
+	segs := splitPath(r.URL.Path)
+	if len(segs) == 0 {
+		writeError(w, http.StatusNotFound, "no service")
+		return
+	}
+
+	handler, ok := s.services[segs[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("unknown service %q", segs[0]))
+		return
+	}
+
+	if segs[0] != "identity" {
+		projectID, ok := s.tokenProject(r.Header.Get("X-Auth-Token"))
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "The request you have made requires authentication.")
+			return
+		}
+		r = r.WithContext(context.WithValue(r.Context(), projectKey{}, projectID))
+	}
+
+	handler(w, r)
+}
+
+// projectKey is the request context key holding the project the request
+// token is scoped to.
+type projectKey struct{}
+
+// requestProject returns the project the token of r is scoped to.
+func (s *Server) requestProject(r *http.Request) string {
+	if projectID, ok := r.Context().Value(projectKey{}).(string); ok {
+		return projectID
+	}
+
+	return s.ProjectID
+}
+
+func (s *Server) seed() {
+	vpcID := s.putLocked("vpcs", map[string]interface{}{
+		"name":        DefaultNetworkName,
+		"cidrv4":      "192.168.0.0/16",
+		"state":       "available",
+		"external":    false,
+		"shared":      false,
+		"tenant_id":   s.ProjectID,
+		"create_time": now(),
+	})
+
+	rtID := s.putLocked("routingtables", map[string]interface{}{
+		"name":          "default_rt",
+		"vpc_id":        vpcID,
+		"default_table": true,
+		"distributed":   true,
+		"gateway_id":    "",
+		"state":         "available",
+		"tenant_id":     s.ProjectID,
+		"create_time":   now(),
+	})
+
+	s.putLocked("vpcsubnets", map[string]interface{}{
+		"name":               "Default Network",
+		"vpc_id":             vpcID,
+		"cidr":               "192.168.0.0/24",
+		"gateway":            "192.168.0.1",
+		"routingtable_id":    rtID,
+		"state":              "available",
+		"shared":             false,
+		"external":           false,
+		"available_ip_count": 250,
+		"tenant_id":          s.ProjectID,
+		"create_time":        now(),
+	})
+
+	s.putLocked("security-groups", map[string]interface{}{
+		"name":                 "default",
+		"description":          "Default security group",
+		"tenant_id":            s.ProjectID,
+		"project_id":           s.ProjectID,
+		"security_group_rules": []interface{}{},
+		"tags":                 []interface{}{},
+	})
+
+	s.putLocked("flavors", map[string]interface{}{
+		"name":  DefaultFlavorName,
+		"vcpus": 1,
+		"ram":   2048,
+		"disk":  0,
+	})
+	s.putLocked("flavors", map[string]interface{}{
+		"name":  "m2.c2m4",
+		"vcpus": 2,
+		"ram":   4096,
+		"disk":  0,
+	})
+
+	s.putLocked("images", map[string]interface{}{
+		"name":       DefaultImageName,
+		"status":     "active",
+		"visibility": "public",
+		"min_disk":   20,
+		"created_at": now(),
+		"updated_at": now(),
+	})
+}
+
+func (s *Server) putLocked(kind string, obj map[string]interface{}) string {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		id = newID()
+		obj["id"] = id
+	}
+
+	if s.store[kind] == nil {
+		s.store[kind] = make(map[string]map[string]interface{})
+	}
+	s.store[kind][id] = obj
+
+	return id
+}
+
+func (s *Server) getLocked(kind, id string) (map[string]interface{}, bool) {
+	obj, ok := s.store[kind][id]
+	return obj, ok
+}
+
+func (s *Server) listLocked(kind string) []map[string]interface{} {
+	ids := make([]string, 0, len(s.store[kind]))
+	for id := range s.store[kind] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	objs := make([]map[string]interface{}, 0, len(ids))
+	for _, id := range ids {
+		objs = append(objs, copyObject(s.store[kind][id]))
+	}
+
+	return objs
+}
+
+func splitPath(p string) []string {
+	var segs []string
+	for _, seg := range strings.Split(p, "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+
+	return segs
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// copyObject returns a deep copy of obj by round-tripping it through JSON,
+// so callers never share maps with the store.
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+
+	var c map[string]interface{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	body := make(map[string]interface{})
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return body, nil
+	}
+
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	if v == nil {
+		w.WriteHeader(code)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": msg,
+		},
+	})
+}
@@ -0,0 +1,336 @@
+package mockcloud
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clusters"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/nodegroups"
+	"github.com/gophercloud/gophercloud/openstack/loadbalancer/v2/loadbalancers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/stretchr/testify/assert"
+)
+
+func newProvider(t *testing.T, s *Server) *gophercloud.ProviderClient {
+	provider, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: s.AuthURL(),
+		Username:         DefaultUserName,
+		Password:         DefaultPassword,
+		TenantID:         s.ProjectID,
+		DomainName:       "Default",
+	})
+	if err != nil {
+		t.Fatalf("Error authenticating against the mock server: %s", err)
+	}
+
+	return provider
+}
+
+func TestUnitMockCloudAuthentication(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	_, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: s.AuthURL(),
+		Username:         DefaultUserName,
+		Password:         "wrong",
+		TenantID:         s.ProjectID,
+		DomainName:       "Default",
+	})
+	assert.Error(t, err)
+
+	provider := newProvider(t, s)
+	assert.Equal(t, tokenID, provider.Token())
+
+	endpointOpts := gophercloud.EndpointOpts{Region: DefaultRegion}
+	compute, err := openstack.NewComputeV2(provider, endpointOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, s.URL+"/compute/v2.1/", compute.Endpoint)
+
+	network, err := openstack.NewNetworkV2(provider, endpointOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, s.URL+"/network/v2.0/", network.ResourceBaseURL())
+
+	_, err = openstack.NewComputeV2(provider, gophercloud.EndpointOpts{Region: "KR2"})
+	assert.Error(t, err)
+}
+
+func TestUnitMockCloudProjectScope(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	provider, err := openstack.AuthenticatedClient(gophercloud.AuthOptions{
+		IdentityEndpoint: s.AuthURL(),
+		Username:         DefaultUserName,
+		Password:         DefaultPassword,
+		DomainName:       "Default",
+		Scope:            &gophercloud.AuthScope{ProjectID: "other-project"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, tokenID+"-other-project", provider.Token())
+
+	client, err := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	network, err := networks.Create(client, networks.CreateOpts{Name: "network_1"}).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "other-project", network.TenantID)
+}
+
+func TestUnitMockCloudNetworkView(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewNetworkV2(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	allPages, err := networks.List(client, networks.ListOpts{Name: DefaultNetworkName}).AllPages()
+	assert.NoError(t, err)
+	all, err := networks.ExtractNetworks(allPages)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, "ACTIVE", all[0].Status)
+	assert.Len(t, all[0].Subnets, 1)
+
+	vpcs := s.List("vpcs")
+	assert.Len(t, vpcs, 1)
+	assert.Equal(t, vpcs[0]["id"], all[0].ID)
+}
+
+func TestUnitMockCloudServerLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewComputeV2(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	allPages, err := flavors.ListDetail(client, nil).AllPages()
+	assert.NoError(t, err)
+	allFlavors, err := flavors.ExtractFlavors(allPages)
+	assert.NoError(t, err)
+	assert.Len(t, allFlavors, 2)
+
+	var flavorID, resizeFlavorID string
+	for _, f := range allFlavors {
+		if f.Name == DefaultFlavorName {
+			flavorID = f.ID
+		} else {
+			resizeFlavorID = f.ID
+		}
+	}
+
+	vpcID := s.List("vpcs")[0]["id"].(string)
+	imageID := s.List("images")[0]["id"].(string)
+
+	server, err := servers.Create(client, servers.CreateOpts{
+		Name:      "instance_1",
+		FlavorRef: flavorID,
+		ImageRef:  imageID,
+		Networks:  []servers.Network{{UUID: vpcID}},
+	}).Extract()
+	assert.NoError(t, err)
+
+	server, err = servers.Get(client, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", server.Status)
+	assert.Contains(t, server.Addresses, DefaultNetworkName)
+	assert.Len(t, s.List("ports"), 1)
+
+	err = servers.Resize(client, server.ID, servers.ResizeOpts{FlavorRef: resizeFlavorID}).ExtractErr()
+	assert.NoError(t, err)
+	server, err = servers.Get(client, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "VERIFY_RESIZE", server.Status)
+
+	err = servers.ConfirmResize(client, server.ID).ExtractErr()
+	assert.NoError(t, err)
+	server, err = servers.Get(client, server.ID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", server.Status)
+	assert.Equal(t, resizeFlavorID, server.Flavor["id"])
+
+	err = servers.Delete(client, server.ID).ExtractErr()
+	assert.NoError(t, err)
+	_, err = servers.Get(client, server.ID).Extract()
+	assert.IsType(t, gophercloud.ErrDefault404{}, err)
+	assert.Len(t, s.List("ports"), 0)
+}
+
+func TestUnitMockCloudLoadBalancerLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewLoadBalancerV2(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	subnetID := s.List("vpcsubnets")[0]["id"].(string)
+
+	lb, err := loadbalancers.Create(client, loadbalancers.CreateOpts{
+		Name:        "loadbalancer_1",
+		VipSubnetID: subnetID,
+	}).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", lb.ProvisioningStatus)
+	assert.NotEmpty(t, lb.VipAddress)
+
+	port, ok := s.Get("ports", lb.VipPortID)
+	assert.True(t, ok)
+	assert.Equal(t, lb.ID, port["device_id"])
+
+	err = loadbalancers.Delete(client, lb.ID, nil).ExtractErr()
+	assert.NoError(t, err)
+	_, ok = s.Get("ports", lb.VipPortID)
+	assert.False(t, ok)
+}
+
+func TestUnitMockCloudClusterNodeGroups(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewContainerInfraV1(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	nodeCount := 2
+	clusterID, err := clusters.Create(client, clusters.CreateOpts{
+		Name:              "cluster_1",
+		ClusterTemplateID: "template",
+		NodeCount:         &nodeCount,
+	}).Extract()
+	assert.NoError(t, err)
+
+	allPages, err := nodegroups.List(client, clusterID, nil).AllPages()
+	assert.NoError(t, err)
+	ngs, err := nodegroups.ExtractNodeGroups(allPages)
+	assert.NoError(t, err)
+	assert.Len(t, ngs, 2)
+
+	var workerID string
+	for _, ng := range ngs {
+		if ng.Role == "worker" {
+			workerID = ng.UUID
+		}
+	}
+
+	ng, err := nodegroups.Update(client, clusterID, workerID, []nodegroups.UpdateOptsBuilder{
+		nodegroups.UpdateOpts{Op: nodegroups.ReplaceOp, Path: "/max_node_count", Value: 5},
+	}).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, *ng.MaxNodeCount)
+
+	_, err = client.Post(client.ServiceURL("clusters", clusterID, "nodegroups", workerID, "autoscale"), map[string]interface{}{
+		"ca_enable":         true,
+		"ca_max_node_count": 7,
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	ng, err = nodegroups.Get(client, clusterID, workerID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "true", ng.Labels["ca_enable"])
+	assert.Equal(t, "7", ng.Labels["ca_max_node_count"])
+
+	_, err = client.Post(client.ServiceURL("clusters", clusterID, "nodegroups", "default-master", "upgrade"), map[string]interface{}{
+		"version": "v1.29.3",
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	cluster, err := clusters.Get(client, clusterID).Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.29.3", cluster.COEVersion)
+
+	err = clusters.Delete(client, clusterID).ExtractErr()
+	assert.NoError(t, err)
+	assert.Len(t, s.List("nodegroups"), 0)
+}
+
+func TestUnitMockCloudClusterUpdate(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewContainerInfraV1(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	var created struct {
+		UUID string `json:"uuid"`
+	}
+	_, err = client.Post(client.ServiceURL("clusters"), map[string]interface{}{
+		"name":                "cluster_1",
+		"cluster_template_id": "template",
+		"addons": []map[string]interface{}{
+			{"name": "calico", "version": "v3.24.1-nks1"},
+		},
+	}, &created, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+	clusterID := created.UUID
+
+	_, err = client.Patch(client.ServiceURL("clusters", clusterID, "addons", "calico"), map[string]interface{}{
+		"version": "v3.28.2-nks1",
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	var addon map[string]interface{}
+	_, err = client.Get(client.ServiceURL("clusters", clusterID, "addons", "calico"), &addon, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "v3.28.2-nks1", addon["version"])
+	assert.Equal(t, "INSTALLED", addon["status"])
+
+	_, err = client.Post(client.ServiceURL("clusters", clusterID, "api_ep_ipacl"), map[string]interface{}{
+		"enable": "True",
+		"action": "ALLOW",
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	var ipacl map[string]interface{}
+	_, err = client.Get(client.ServiceURL("clusters", clusterID, "api_ep_ipacl"), &ipacl, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "True", ipacl["enable"])
+
+	_, err = client.Post(client.ServiceURL("clusters", clusterID, "actions", "resize"), map[string]interface{}{
+		"node_count": 3,
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	ng, err := nodegroups.Get(client, clusterID, "default-worker").Extract()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ng.NodeCount)
+}
+
+func TestUnitMockCloudClusterAddons(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := openstack.NewContainerInfraV1(newProvider(t, s), gophercloud.EndpointOpts{Region: DefaultRegion})
+	assert.NoError(t, err)
+
+	clusterID, err := clusters.Create(client, clusters.CreateOpts{
+		Name:              "cluster_1",
+		ClusterTemplateID: "template",
+	}).Extract()
+	assert.NoError(t, err)
+
+	_, err = client.Post(client.ServiceURL("clusters", clusterID, "addons"), map[string]interface{}{
+		"name":    "metrics-server",
+		"version": "v0.7.1-nks1",
+	}, nil, &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	var list struct {
+		Addons []map[string]interface{} `json:"addons"`
+	}
+	_, err = client.Get(client.ServiceURL("clusters", clusterID, "addons"), &list, nil)
+	assert.NoError(t, err)
+	assert.Len(t, list.Addons, 1)
+	assert.Equal(t, "INSTALLED", list.Addons[0]["status"])
+
+	_, err = client.Delete(client.ServiceURL("clusters", clusterID, "addons", "metrics-server"), &gophercloud.RequestOpts{OkCodes: []int{202}})
+	assert.NoError(t, err)
+
+	_, err = client.Get(client.ServiceURL("clusters", clusterID, "addons", "metrics-server"), nil, nil)
+	assert.Error(t, err)
+
+	_, err = client.Get(client.ServiceURL("addons")+"?k8s_version=v1.28.3", &list, nil)
+	assert.NoError(t, err)
+	assert.Len(t, list.Addons, 3)
+}
@@ -0,0 +1,291 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// kubernetesAddonV1 is an addon installed in an NKS cluster. The nhncloud
+// kubernetes packages have no addon API, so it is called directly.
+type kubernetesAddonV1 struct {
+	Name    string                 `json:"name"`
+	Version string                 `json:"version"`
+	Status  string                 `json:"status,omitempty"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+func kubernetesAddonV1List(client *gophercloud.ServiceClient, clusterID string) ([]kubernetesAddonV1, error) {
+	var r struct {
+		Addons []kubernetesAddonV1 `json:"addons"`
+	}
+	_, err := client.Get(client.ServiceURL("clusters", clusterID, "addons"), &r, nil)
+
+	return r.Addons, err
+}
+
+func kubernetesAddonV1Get(client *gophercloud.ServiceClient, clusterID, name string) (*kubernetesAddonV1, error) {
+	var r kubernetesAddonV1
+	_, err := client.Get(client.ServiceURL("clusters", clusterID, "addons", name), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+// kubernetesAddonV1Install installs an addon in a cluster.
+func kubernetesAddonV1Install(client *gophercloud.ServiceClient, clusterID string, addon kubernetesAddonV1) error {
+	b := map[string]interface{}{
+		"name":    addon.Name,
+		"version": addon.Version,
+	}
+	if addon.Options != nil {
+		b["options"] = addon.Options
+	}
+
+	_, err := client.Post(client.ServiceURL("clusters", clusterID, "addons"), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 201, 202},
+	})
+
+	return err
+}
+
+// kubernetesAddonV1Uninstall removes an addon from a cluster.
+func kubernetesAddonV1Uninstall(client *gophercloud.ServiceClient, clusterID, name string) error {
+	_, err := client.Delete(client.ServiceURL("clusters", clusterID, "addons", name), &gophercloud.RequestOpts{
+		OkCodes: []int{202, 204},
+	})
+
+	return err
+}
+
+// kubernetesAddonV1Update changes the version and options of an installed
+// addon in place.
+func kubernetesAddonV1Update(client *gophercloud.ServiceClient, clusterID string, addon kubernetesAddonV1) error {
+	b := map[string]interface{}{
+		"version": addon.Version,
+	}
+	if addon.Options != nil {
+		b["options"] = addon.Options
+	}
+
+	_, err := client.Patch(client.ServiceURL("clusters", clusterID, "addons", addon.Name), b, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+
+	return err
+}
+
+// kubernetesAddonV1StateRefreshFunc returns a resource.StateRefreshFunc that
+// is used to watch an addon of a NHN Cloud NKS cluster.
+func kubernetesAddonV1StateRefreshFunc(client *gophercloud.ServiceClient, clusterID, name string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		addon, err := kubernetesAddonV1Get(client, clusterID, name)
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return &kubernetesAddonV1{Name: name}, "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		if addon.Status == "FAILED" {
+			return addon, addon.Status, fmt.Errorf("addon %s of nhncloud_kubernetes_cluster_v1 %s is in an error state", name, clusterID)
+		}
+
+		return addon, addon.Status, nil
+	}
+}
+
+// kubernetesAddonV1WaitForStatus waits for an addon to finish installing or
+// updating.
+func kubernetesAddonV1WaitForStatus(ctx context.Context, client *gophercloud.ServiceClient, clusterID, name string, timeout string, d *schema.ResourceData) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"INSTALLING", "UPDATING"},
+		Target:     []string{"INSTALLED"},
+		Refresh:    kubernetesAddonV1StateRefreshFunc(client, clusterID, name),
+		Timeout:    d.Timeout(timeout),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for addon %s of nhncloud_kubernetes_cluster_v1 %s to be installed: %s", name, clusterID, err)
+	}
+
+	return nil
+}
+
+// kubernetesAddonV1Type is an addon that can be installed in clusters of a
+// Kubernetes version, together with its available versions.
+type kubernetesAddonV1Type struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Versions       []string `json:"versions"`
+	DefaultVersion string   `json:"default_version"`
+}
+
+type kubernetesAddonV1ListTypesOpts struct {
+	K8sVersion string `q:"k8s_version"`
+}
+
+// kubernetesAddonV1ListTypes returns the addons available for a Kubernetes
+// version.
+func kubernetesAddonV1ListTypes(client *gophercloud.ServiceClient, k8sVersion string) ([]kubernetesAddonV1Type, error) {
+	q, err := gophercloud.BuildQueryString(kubernetesAddonV1ListTypesOpts{K8sVersion: k8sVersion})
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Addons []kubernetesAddonV1Type `json:"addons"`
+	}
+	_, err = client.Get(client.ServiceURL("addons")+q.String(), &r, nil)
+
+	return r.Addons, err
+}
+
+func expandKubernetesClusterV1Addons(v []interface{}) []kubernetesAddonV1 {
+	addons := make([]kubernetesAddonV1, 0, len(v))
+	for _, raw := range v {
+		addonMap, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		addon := kubernetesAddonV1{
+			Name:    addonMap["name"].(string),
+			Version: addonMap["version"].(string),
+		}
+		if options, ok := addonMap["options"].(map[string]interface{}); ok && len(options) > 0 {
+			addon.Options = options
+		}
+		addons = append(addons, addon)
+	}
+
+	return addons
+}
+
+// kubernetesClusterV1AddedAddons returns the addons of newAddons that are
+// not part of oldAddons.
+func kubernetesClusterV1AddedAddons(oldAddons, newAddons []kubernetesAddonV1) []kubernetesAddonV1 {
+	old := make(map[string]struct{}, len(oldAddons))
+	for _, addon := range oldAddons {
+		old[addon.Name] = struct{}{}
+	}
+
+	var added []kubernetesAddonV1
+	for _, addon := range newAddons {
+		if _, ok := old[addon.Name]; !ok {
+			added = append(added, addon)
+		}
+	}
+
+	return added
+}
+
+// kubernetesClusterV1ChangedAddons returns the addons of newAddons whose
+// version or options differ from oldAddons. Added addons are not included.
+func kubernetesClusterV1ChangedAddons(oldAddons, newAddons []kubernetesAddonV1) []kubernetesAddonV1 {
+	old := make(map[string]kubernetesAddonV1, len(oldAddons))
+	for _, addon := range oldAddons {
+		old[addon.Name] = addon
+	}
+
+	var changed []kubernetesAddonV1
+	for _, addon := range newAddons {
+		o, ok := old[addon.Name]
+		if !ok {
+			continue
+		}
+		if o.Version != addon.Version || fmt.Sprint(o.Options) != fmt.Sprint(addon.Options) {
+			changed = append(changed, addon)
+		}
+	}
+
+	return changed
+}
+
+// kubernetesClusterV1IPACL is the IP access control of the API endpoint of
+// an NKS cluster.
+type kubernetesClusterV1IPACL struct {
+	Enable  string                           `json:"enable"`
+	Action  string                           `json:"action,omitempty"`
+	Targets []kubernetesClusterV1IPACLTarget `json:"ipacl_targets,omitempty"`
+}
+
+type kubernetesClusterV1IPACLTarget struct {
+	CIDRAddress string `json:"cidr_address"`
+	Description string `json:"description,omitempty"`
+}
+
+func kubernetesClusterV1GetIPACL(client *gophercloud.ServiceClient, clusterID string) (*kubernetesClusterV1IPACL, error) {
+	var r kubernetesClusterV1IPACL
+	_, err := client.Get(client.ServiceURL("clusters", clusterID, "api_ep_ipacl"), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func kubernetesClusterV1SetIPACL(client *gophercloud.ServiceClient, clusterID string, ipacl kubernetesClusterV1IPACL) error {
+	_, err := client.Post(client.ServiceURL("clusters", clusterID, "api_ep_ipacl"), ipacl, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+
+	return err
+}
+
+func expandKubernetesClusterV1IPACL(v []interface{}) kubernetesClusterV1IPACL {
+	if len(v) == 0 || v[0] == nil {
+		return kubernetesClusterV1IPACL{Enable: "False"}
+	}
+
+	raw := v[0].(map[string]interface{})
+	ipacl := kubernetesClusterV1IPACL{
+		Enable: "False",
+		Action: raw["action"].(string),
+	}
+	if raw["enabled"].(bool) {
+		ipacl.Enable = "True"
+	}
+
+	for _, t := range raw["target"].([]interface{}) {
+		target := t.(map[string]interface{})
+		ipacl.Targets = append(ipacl.Targets, kubernetesClusterV1IPACLTarget{
+			CIDRAddress: target["cidr_address"].(string),
+			Description: target["description"].(string),
+		})
+	}
+
+	return ipacl
+}
+
+func flattenKubernetesClusterV1IPACL(ipacl *kubernetesClusterV1IPACL) []map[string]interface{} {
+	enabled := ipacl.Enable == "True" || ipacl.Enable == "true"
+	action := ipacl.Action
+	if action == "" {
+		action = "ALLOW"
+	}
+	targets := make([]map[string]interface{}, 0, len(ipacl.Targets))
+	for _, target := range ipacl.Targets {
+		targets = append(targets, map[string]interface{}{
+			"cidr_address": target.CIDRAddress,
+			"description":  target.Description,
+		})
+	}
+
+	return []map[string]interface{}{
+		{
+			"enabled": enabled,
+			"action":  action,
+			"target":  targets,
+		},
+	}
+}
@@ -0,0 +1,57 @@
+package nhncloud
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitKubernetesClusterV1ChangedAddons(t *testing.T) {
+	oldAddons := expandKubernetesClusterV1Addons([]interface{}{
+		map[string]interface{}{"name": "calico", "version": "v3.24.1-nks1"},
+		map[string]interface{}{"name": "coredns", "version": "1.8.4-nks1", "options": map[string]interface{}{"replicas": "2"}},
+		map[string]interface{}{"name": "kube-proxy", "version": "v1.28.3-nks1"},
+	})
+	newAddons := expandKubernetesClusterV1Addons([]interface{}{
+		map[string]interface{}{"name": "kube-proxy", "version": "v1.28.3-nks1"},
+		map[string]interface{}{"name": "calico", "version": "v3.28.2-nks1"},
+		map[string]interface{}{"name": "coredns", "version": "1.8.4-nks1", "options": map[string]interface{}{"replicas": "3"}},
+	})
+
+	changed := kubernetesClusterV1ChangedAddons(oldAddons, newAddons)
+	assert.Len(t, changed, 2)
+	assert.Equal(t, "calico", changed[0].Name)
+	assert.Equal(t, "v3.28.2-nks1", changed[0].Version)
+	assert.Equal(t, "coredns", changed[1].Name)
+	assert.Equal(t, "3", changed[1].Options["replicas"])
+
+	assert.Empty(t, kubernetesClusterV1AddedAddons(oldAddons, newAddons))
+
+	added := kubernetesClusterV1AddedAddons(newAddons[:2], oldAddons)
+	assert.Len(t, added, 1)
+	assert.Equal(t, "coredns", added[0].Name)
+}
+
+func TestUnitKubernetesClusterV1IPACL(t *testing.T) {
+	ipacl := expandKubernetesClusterV1IPACL([]interface{}{
+		map[string]interface{}{
+			"enabled": true,
+			"action":  "DENY",
+			"target": []interface{}{
+				map[string]interface{}{"cidr_address": "10.0.0.0/24", "description": "office"},
+			},
+		},
+	})
+
+	assert.Equal(t, "True", ipacl.Enable)
+	assert.Equal(t, "DENY", ipacl.Action)
+	assert.Equal(t, []kubernetesClusterV1IPACLTarget{{CIDRAddress: "10.0.0.0/24", Description: "office"}}, ipacl.Targets)
+
+	flattened := flattenKubernetesClusterV1IPACL(&ipacl)
+	assert.Equal(t, true, flattened[0]["enabled"])
+	assert.Equal(t, "DENY", flattened[0]["action"])
+	assert.Len(t, flattened[0]["target"], 1)
+
+	assert.Equal(t, "False", expandKubernetesClusterV1IPACL(nil).Enable)
+	assert.Equal(t, "ALLOW", flattenKubernetesClusterV1IPACL(&kubernetesClusterV1IPACL{Enable: "False"})[0]["action"])
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -17,6 +18,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/terraform/hashcode"
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/certificates"
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/clusters"
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/clustertemplates"
@@ -181,6 +183,162 @@ func kubernetesNodeGroupV1AppendUpdateOpts(updateOpts []nodegroups.UpdateOptsBui
 	return updateOpts
 }
 
+// kubernetesNodeGroupV1AutoscaleLabels maps the arguments of the autoscale
+// block to the node group labels the NKS cluster autoscaler reads.
+var kubernetesNodeGroupV1AutoscaleLabels = map[string]string{
+	"enabled":                    "ca_enable",
+	"min_node_count":             "ca_min_node_count",
+	"max_node_count":             "ca_max_node_count",
+	"scale_down_enabled":         "ca_scale_down_enable",
+	"scale_down_unneeded_time":   "ca_scale_down_unneeded_time",
+	"scale_down_util_threshold":  "ca_scale_down_util_thresh",
+	"scale_down_delay_after_add": "ca_scale_down_delay_after_add",
+}
+
+// expandKubernetesNodeGroupV1Autoscale returns the autoscale block keyed by
+// node group label, or nil if the block is not set.
+func expandKubernetesNodeGroupV1Autoscale(v []interface{}) map[string]interface{} {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+
+	autoscale := v[0].(map[string]interface{})
+	labels := make(map[string]interface{}, len(kubernetesNodeGroupV1AutoscaleLabels))
+	for key, label := range kubernetesNodeGroupV1AutoscaleLabels {
+		labels[label] = autoscale[key]
+	}
+
+	return labels
+}
+
+// flattenKubernetesNodeGroupV1Autoscale reads the autoscale block back from
+// the node group labels. Node groups without a ca_enable label have none.
+func flattenKubernetesNodeGroupV1Autoscale(labels map[string]interface{}) []map[string]interface{} {
+	if _, ok := labels["ca_enable"]; !ok {
+		return nil
+	}
+
+	autoscale := make(map[string]interface{}, len(kubernetesNodeGroupV1AutoscaleLabels))
+	for key, label := range kubernetesNodeGroupV1AutoscaleLabels {
+		val, ok := labels[label]
+		if !ok {
+			continue
+		}
+
+		str := flattenKubernetesV1LabelValue(val)
+		switch key {
+		case "enabled", "scale_down_enabled":
+			autoscale[key], _ = strconv.ParseBool(str)
+		default:
+			autoscale[key], _ = strconv.Atoi(str)
+		}
+	}
+
+	return []map[string]interface{}{autoscale}
+}
+
+// kubernetesNodeGroupV1Autoscale changes the cluster autoscaler settings of a
+// node group in place through the NKS autoscale API.
+func kubernetesNodeGroupV1Autoscale(client *gophercloud.ServiceClient, clusterID, nodeGroupID string, autoscale map[string]interface{}) error {
+	_, err := client.Post(client.ServiceURL("clusters", clusterID, "nodegroups", nodeGroupID, "autoscale"), autoscale, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{200, 202},
+	})
+
+	return err
+}
+
+// kubernetesNodeGroupV1K8sNodes holds the Kubernetes node labels and taints
+// of a node group, which the nodegroups package does not extract.
+type kubernetesNodeGroupV1K8sNodes struct {
+	Labels map[string]string            `json:"k8s_node_labels"`
+	Taints []kubernetesNodeGroupV1Taint `json:"k8s_taints"`
+}
+
+// kubernetesNodeGroupV1GetK8sNodes returns the current Kubernetes node labels
+// and taints of a node group.
+func kubernetesNodeGroupV1GetK8sNodes(client *gophercloud.ServiceClient, clusterID, nodeGroupID string) (*kubernetesNodeGroupV1K8sNodes, error) {
+	var k8sNodes kubernetesNodeGroupV1K8sNodes
+	if err := nodegroups.Get(client, clusterID, nodeGroupID).ExtractInto(&k8sNodes); err != nil {
+		return nil, err
+	}
+
+	return &k8sNodes, nil
+}
+
+// flattenKubernetesNodeGroupV1K8sNodeLabels returns the node labels that are
+// managed by Terraform. Labels that the system adds to the nodes are left out.
+func flattenKubernetesNodeGroupV1K8sNodeLabels(labels map[string]string, managed map[string]interface{}) map[string]string {
+	v := make(map[string]string)
+	for key := range managed {
+		if value, ok := labels[key]; ok {
+			v[key] = value
+		}
+	}
+
+	return v
+}
+
+// expandKubernetesNodeGroupV1K8sNodeLabels returns the node labels to replace
+// the current ones with. Labels that were removed from the configuration are
+// dropped, the others are kept, so labels added by the system survive.
+func expandKubernetesNodeGroupV1K8sNodeLabels(current map[string]string, o, n map[string]interface{}) map[string]string {
+	labels := make(map[string]string, len(current)+len(n))
+	for key, value := range current {
+		labels[key] = value
+	}
+
+	for key := range o {
+		delete(labels, key)
+	}
+
+	for key, value := range n {
+		labels[key] = value.(string)
+	}
+
+	return labels
+}
+
+type kubernetesNodeGroupV1Taint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"`
+}
+
+func expandKubernetesNodeGroupV1Taints(v []interface{}) []kubernetesNodeGroupV1Taint {
+	taints := make([]kubernetesNodeGroupV1Taint, 0, len(v))
+	for _, raw := range v {
+		taint := raw.(map[string]interface{})
+		taints = append(taints, kubernetesNodeGroupV1Taint{
+			Key:    taint["key"].(string),
+			Value:  taint["value"].(string),
+			Effect: taint["effect"].(string),
+		})
+	}
+
+	return taints
+}
+
+// kubernetesNodeGroupV1TaintHash identifies a taint by its key and effect,
+// as Kubernetes does.
+func kubernetesNodeGroupV1TaintHash(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	return hashcode.String(fmt.Sprintf("%s-%s", m["key"].(string), m["effect"].(string)))
+}
+
+func flattenKubernetesNodeGroupV1Taints(taints []kubernetesNodeGroupV1Taint) []map[string]interface{} {
+	v := make([]map[string]interface{}, 0, len(taints))
+	for _, taint := range taints {
+		v = append(v, map[string]interface{}{
+			"key":    taint.Key,
+			"value":  taint.Value,
+			"effect": taint.Effect,
+		})
+	}
+
+	return v
+}
+
 // KubernetesClusterV1StateRefreshFunc returns a resource.StateRefreshFunc
 // that is used to watch a NHN Cloud NKS Cluster.
 func kubernetesClusterV1StateRefreshFunc(client *gophercloud.ServiceClient, clusterID string) resource.StateRefreshFunc {
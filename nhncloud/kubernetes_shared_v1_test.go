@@ -106,3 +106,111 @@ func TestUnitKubernetesClusterTemplateV1AppendUpdateOpts(t *testing.T) {
 
 	assert.Equal(t, expectedUpdateOpts, actualUpdateOpts)
 }
+
+func TestUnitExpandKubernetesNodeGroupV1Autoscale(t *testing.T) {
+	assert.Nil(t, expandKubernetesNodeGroupV1Autoscale(nil))
+
+	autoscale := []interface{}{
+		map[string]interface{}{
+			"enabled":                    true,
+			"min_node_count":             2,
+			"max_node_count":             8,
+			"scale_down_enabled":         false,
+			"scale_down_unneeded_time":   15,
+			"scale_down_util_threshold":  40,
+			"scale_down_delay_after_add": 5,
+		},
+	}
+
+	expected := map[string]interface{}{
+		"ca_enable":                     true,
+		"ca_min_node_count":             2,
+		"ca_max_node_count":             8,
+		"ca_scale_down_enable":          false,
+		"ca_scale_down_unneeded_time":   15,
+		"ca_scale_down_util_thresh":     40,
+		"ca_scale_down_delay_after_add": 5,
+	}
+
+	assert.Equal(t, expected, expandKubernetesNodeGroupV1Autoscale(autoscale))
+}
+
+func TestUnitFlattenKubernetesNodeGroupV1Autoscale(t *testing.T) {
+	assert.Nil(t, flattenKubernetesNodeGroupV1Autoscale(map[string]interface{}{"boot_volume_size": "20"}))
+
+	labels := map[string]interface{}{
+		"boot_volume_size":              "20",
+		"ca_enable":                     "True",
+		"ca_min_node_count":             "1",
+		"ca_max_node_count":             float64(5),
+		"ca_scale_down_enable":          "false",
+		"ca_scale_down_unneeded_time":   "10",
+		"ca_scale_down_util_thresh":     "50",
+		"ca_scale_down_delay_after_add": "10",
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"enabled":                    true,
+			"min_node_count":             1,
+			"max_node_count":             5,
+			"scale_down_enabled":         false,
+			"scale_down_unneeded_time":   10,
+			"scale_down_util_threshold":  50,
+			"scale_down_delay_after_add": 10,
+		},
+	}
+
+	assert.Equal(t, expected, flattenKubernetesNodeGroupV1Autoscale(labels))
+}
+
+func TestUnitKubernetesNodeGroupV1Taints(t *testing.T) {
+	raw := []interface{}{
+		map[string]interface{}{"key": "dedicated", "value": "gpu", "effect": "NoSchedule"},
+		map[string]interface{}{"key": "spot", "value": "", "effect": "PreferNoSchedule"},
+	}
+
+	expected := []kubernetesNodeGroupV1Taint{
+		{Key: "dedicated", Value: "gpu", Effect: "NoSchedule"},
+		{Key: "spot", Effect: "PreferNoSchedule"},
+	}
+
+	taints := expandKubernetesNodeGroupV1Taints(raw)
+	assert.Equal(t, expected, taints)
+
+	flattened := flattenKubernetesNodeGroupV1Taints(taints)
+	assert.Len(t, flattened, 2)
+	assert.Equal(t, raw[0], flattened[0])
+	assert.Equal(t, raw[1], flattened[1])
+
+	assert.Empty(t, expandKubernetesNodeGroupV1Taints(nil))
+}
+
+func TestUnitKubernetesNodeGroupV1TaintHash(t *testing.T) {
+	gpu := map[string]interface{}{"key": "dedicated", "value": "gpu", "effect": "NoSchedule"}
+	cpu := map[string]interface{}{"key": "dedicated", "value": "cpu", "effect": "NoSchedule"}
+	noExecute := map[string]interface{}{"key": "dedicated", "value": "gpu", "effect": "NoExecute"}
+
+	assert.Equal(t, kubernetesNodeGroupV1TaintHash(gpu), kubernetesNodeGroupV1TaintHash(cpu))
+	assert.NotEqual(t, kubernetesNodeGroupV1TaintHash(gpu), kubernetesNodeGroupV1TaintHash(noExecute))
+}
+
+func TestUnitKubernetesNodeGroupV1K8sNodeLabels(t *testing.T) {
+	current := map[string]string{
+		"app":                       "web",
+		"tier":                      "frontend",
+		"node.kubernetes.io/system": "true",
+	}
+
+	assert.Equal(t, map[string]string{"app": "web", "tier": "frontend"},
+		flattenKubernetesNodeGroupV1K8sNodeLabels(current, map[string]interface{}{"app": "web", "tier": "frontend"}))
+	assert.Empty(t, flattenKubernetesNodeGroupV1K8sNodeLabels(current, nil))
+
+	o := map[string]interface{}{"app": "web", "tier": "frontend"}
+	n := map[string]interface{}{"app": "api"}
+	expected := map[string]string{
+		"app":                       "api",
+		"node.kubernetes.io/system": "true",
+	}
+	assert.Equal(t, expected, expandKubernetesNodeGroupV1K8sNodeLabels(current, o, n))
+}
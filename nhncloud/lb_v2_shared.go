@@ -91,15 +91,10 @@ func chooseLBV2LoadbalancerUpdateOpts(d *schema.ResourceData, config *Config) (n
 			asu := d.Get("admin_state_up").(bool)
 			updateOpts.AdminStateUp = &asu
 		}
-		if d.HasChange("tags") {
+		if d.HasChanges("tags", "all_tags") {
 			hasChange = true
-			if v, ok := d.GetOk("tags"); ok {
-				tags := v.(*schema.Set).List()
-				tagsToUpdate := expandToStringSlice(tags)
-				updateOpts.Tags = &tagsToUpdate
-			} else {
-				updateOpts.Tags = &[]string{}
-			}
+			tagsToUpdate := expandObjectUpdateTags(d, config.DefaultTags)
+			updateOpts.Tags = &tagsToUpdate
 		}
 
 		if hasChange {
@@ -188,10 +183,7 @@ func chooseLBV2ListenerCreateOpts(d *schema.ResourceData, config *Config) (neutr
 			opts.TimeoutTCPInspect = &timeoutTCPInspect
 		}
 
-		if v, ok := d.GetOk("tags"); ok {
-			tags := v.(*schema.Set).List()
-			opts.Tags = expandToStringSlice(tags)
-		}
+		opts.Tags = expandObjectTags(d, config.DefaultTags)
 
 		if v, ok := d.GetOk("keepalive_timeout"); ok {
 			keepaliveTimeout := v.(int)
@@ -352,15 +344,10 @@ func chooseLBV2ListenerUpdateOpts(d *schema.ResourceData, config *Config) (neutr
 			opts.AllowedCIDRs = &allowedCidrs
 		}
 
-		if d.HasChange("tags") {
+		if d.HasChanges("tags", "all_tags") {
 			hasChange = true
-			if v, ok := d.GetOk("tags"); ok {
-				tags := v.(*schema.Set).List()
-				tagsToUpdate := expandToStringSlice(tags)
-				opts.Tags = &tagsToUpdate
-			} else {
-				opts.Tags = &[]string{}
-			}
+			tagsToUpdate := expandObjectUpdateTags(d, config.DefaultTags)
+			opts.Tags = &tagsToUpdate
 		}
 
 		if d.HasChange("keepalive_timeout") {
@@ -0,0 +1,288 @@
+package nhncloud
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// httpLogSubsystem logs the API calls. Its level is set by the
+	// TF_LOG_PROVIDER_NHNCLOUD_HTTP environment variable.
+	httpLogSubsystem = "http"
+
+	// authLogSubsystem logs the token requests. Its level is set by the
+	// TF_LOG_PROVIDER_NHNCLOUD_AUTH environment variable.
+	authLogSubsystem = "auth"
+
+	redactedLogValue = "***"
+)
+
+// sensitiveLogHeaders are the lower-cased headers whose values are never
+// logged.
+var sensitiveLogHeaders = map[string]struct{}{
+	"authorization":                   {},
+	"proxy-authorization":             {},
+	"set-cookie":                      {},
+	"x-auth-key":                      {},
+	"x-auth-token":                    {},
+	"x-service-token":                 {},
+	"x-storage-token":                 {},
+	"x-subject-token":                 {},
+	"x-tc-authentication-secretkey":   {},
+	"x-account-meta-temp-url-key":     {},
+	"x-account-meta-temp-url-key-2":   {},
+	"x-container-meta-temp-url-key":   {},
+	"x-container-meta-temp-url-key-2": {},
+}
+
+// sensitiveLogFields are the lower-cased JSON fields whose values are never
+// logged, at any depth of a request or response body.
+var sensitiveLogFields = map[string]struct{}{
+	"adminpass":         {},
+	"admin_pass":        {},
+	"password":          {},
+	"new_password":      {},
+	"original_password": {},
+	"private_key":       {},
+	"secret":            {},
+	"payload":           {},
+	"access_token":      {},
+	"auth_token":        {},
+	"user_data":         {},
+}
+
+// newLoggingContext returns a context carrying the provider HTTP and auth
+// log subsystems, with the given secrets masked wherever they appear. The
+// level of each subsystem comes from its TF_LOG_PROVIDER_NHNCLOUD_*
+// variable, or is DEBUG when debug is set and the variable is not.
+func newLoggingContext(ctx context.Context, debug bool, secrets ...string) context.Context {
+	var masked []string
+	for _, secret := range secrets {
+		if secret != "" {
+			masked = append(masked, secret)
+		}
+	}
+
+	for _, subsystem := range []string{httpLogSubsystem, authLogSubsystem} {
+		level := tflog.WithLevelFromEnv("TF_LOG_PROVIDER_NHNCLOUD", subsystem)
+		if debug && os.Getenv(logSubsystemEnv(subsystem)) == "" {
+			level = tflog.WithLevel(hclog.Debug)
+		}
+		ctx = tflog.NewSubsystem(ctx, subsystem, level)
+		if len(masked) > 0 {
+			ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, subsystem, masked...)
+			ctx = tflog.SubsystemMaskMessageStrings(ctx, subsystem, masked...)
+		}
+	}
+
+	return ctx
+}
+
+func logSubsystemEnv(subsystem string) string {
+	return "TF_LOG_PROVIDER_NHNCLOUD_" + strings.ToUpper(subsystem)
+}
+
+// logSubsystemDebug reports whether a log subsystem created by
+// newLoggingContext emits DEBUG entries. Without a level of its own, the
+// subsystem follows the provider log level, which Terraform takes from
+// TF_LOG_PROVIDER_NHNCLOUD, TF_LOG_PROVIDER or TF_LOG.
+func logSubsystemDebug(subsystem string, debug bool) bool {
+	level := hclog.LevelFromString(os.Getenv(logSubsystemEnv(subsystem)))
+	if level == hclog.NoLevel && debug {
+		level = hclog.Debug
+	}
+
+	for _, env := range []string{"TF_LOG_PROVIDER_NHNCLOUD", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level != hclog.NoLevel {
+			break
+		}
+		if v := os.Getenv(env); strings.EqualFold(v, "JSON") {
+			level = hclog.Trace
+		} else {
+			level = hclog.LevelFromString(v)
+		}
+	}
+
+	return level != hclog.NoLevel && level <= hclog.Debug
+}
+
+// loggingRoundTripper logs every request and response through tflog, with
+// secrets redacted, a transaction ID to correlate both and the duration of
+// the call.
+type loggingRoundTripper struct {
+	rt http.RoundTripper
+
+	// ctx carries the provider loggers. It is only used for logging, so it
+	// does not matter that it may be cancelled.
+	ctx context.Context
+
+	// enabled holds the subsystems that log at DEBUG. Requests of the other
+	// subsystems are passed through, without reading their bodies.
+	enabled map[string]bool
+
+	transactions uint64
+}
+
+func newLoggingRoundTripper(ctx context.Context, rt http.RoundTripper, debug bool) *loggingRoundTripper {
+	enabled := make(map[string]bool)
+	for _, subsystem := range []string{httpLogSubsystem, authLogSubsystem} {
+		enabled[subsystem] = logSubsystemDebug(subsystem, debug)
+	}
+
+	return &loggingRoundTripper{
+		rt:      rt,
+		ctx:     ctx,
+		enabled: enabled,
+	}
+}
+
+func (rt *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	subsystem := httpLogSubsystem
+	if strings.HasSuffix(req.URL.Path, "/tokens") {
+		subsystem = authLogSubsystem
+	}
+
+	if !rt.enabled[subsystem] {
+		return rt.rt.RoundTrip(req)
+	}
+
+	fields := map[string]interface{}{
+		"tf_http_trans_id":    atomic.AddUint64(&rt.transactions, 1),
+		"tf_http_req_method":  req.Method,
+		"tf_http_req_uri":     req.URL.String(),
+		"tf_http_req_headers": redactLogHeaders(req.Header),
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && isJSONContentType(req.Header.Get("Content-Type")) {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		fields["tf_http_req_body"] = redactLogJSON(body)
+	}
+
+	tflog.SubsystemDebug(rt.ctx, subsystem, "Sending HTTP request", fields)
+
+	start := time.Now()
+	resp, err := rt.rt.RoundTrip(req)
+
+	fields = map[string]interface{}{
+		"tf_http_trans_id":    fields["tf_http_trans_id"],
+		"tf_http_req_method":  req.Method,
+		"tf_http_req_uri":     req.URL.String(),
+		"tf_http_duration_ms": time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(rt.ctx, subsystem, "HTTP request failed", fields)
+		return resp, err
+	}
+
+	fields["tf_http_res_status_code"] = resp.StatusCode
+	fields["tf_http_res_headers"] = redactLogHeaders(resp.Header)
+	for _, header := range []string{"X-Openstack-Request-Id", "X-Compute-Request-Id"} {
+		if v := resp.Header.Get(header); v != "" {
+			fields["tf_http_res_request_id"] = v
+			break
+		}
+	}
+
+	if isJSONContentType(resp.Header.Get("Content-Type")) {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		fields["tf_http_res_body"] = redactLogJSON(body)
+	}
+
+	tflog.SubsystemDebug(rt.ctx, subsystem, "Received HTTP response", fields)
+
+	return resp, nil
+}
+
+func isJSONContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/json") ||
+		(strings.HasPrefix(contentType, "application/") && strings.HasSuffix(contentType, "json-patch"))
+}
+
+// redactLogHeaders returns the headers to log, with the values of sensitive
+// headers replaced.
+func redactLogHeaders(headers http.Header) map[string]string {
+	m := make(map[string]string, len(headers))
+	for k, v := range headers {
+		if _, ok := sensitiveLogHeaders[strings.ToLower(k)]; ok {
+			m[k] = redactedLogValue
+			continue
+		}
+		m[k] = strings.Join(v, ", ")
+	}
+
+	return m
+}
+
+// redactLogJSON returns a JSON body to log, with the values of sensitive
+// fields replaced. A body that is not valid JSON is not logged at all, since
+// it cannot be redacted.
+func redactLogJSON(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "(not logged: the body is not valid JSON)"
+	}
+
+	redacted, err := json.Marshal(redactLogValue(data))
+	if err != nil {
+		return "(not logged: the body cannot be redacted)"
+	}
+
+	return string(redacted)
+}
+
+func redactLogValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, value := range v {
+			if _, ok := sensitiveLogFields[strings.ToLower(k)]; ok && value != nil {
+				v[k] = redactedLogValue
+				continue
+			}
+			// The Keystone token response holds the catalog and expiry
+			// next to the token ID, so only the ID is masked.
+			if strings.ToLower(k) == "token" {
+				if token, ok := value.(map[string]interface{}); ok {
+					if _, ok := token["id"]; ok {
+						token["id"] = redactedLogValue
+					}
+				} else if value != nil {
+					v[k] = redactedLogValue
+					continue
+				}
+			}
+			v[k] = redactLogValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactLogValue(value)
+		}
+	}
+
+	return v
+}
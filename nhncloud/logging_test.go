@@ -0,0 +1,108 @@
+package nhncloud
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitLoggingRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Openstack-Request-Id", "req-1234")
+		w.Header().Set("X-Subject-Token", "subject-token")
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"server": {"id": "server-1", "adminPass": "generated-password"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := newLoggingContext(tflogtest.RootLogger(context.Background(), &output), true, "provider-password")
+	client := &http.Client{Transport: newLoggingRoundTripper(ctx, http.DefaultTransport, true)}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v2/servers", strings.NewReader(
+		`{"server": {"name": "instance_1", "admin_pass": "my-password", "user_data": "c2VjcmV0", "metadata": {"note": "provider-password"}}}`))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", "auth-token")
+
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	logged := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	request, response := entries[0], entries[1]
+	assert.Equal(t, "Sending HTTP request", request["@message"])
+	assert.True(t, strings.HasSuffix(request["@module"].(string), ".http"))
+	assert.Equal(t, float64(1), request["tf_http_trans_id"])
+	assert.Equal(t, "***", request["tf_http_req_headers"].(map[string]interface{})["X-Auth-Token"])
+	assert.Contains(t, request["tf_http_req_body"], `"instance_1"`)
+
+	assert.Equal(t, "Received HTTP response", response["@message"])
+	assert.Equal(t, float64(1), response["tf_http_trans_id"])
+	assert.Equal(t, float64(http.StatusAccepted), response["tf_http_res_status_code"])
+	assert.Equal(t, "req-1234", response["tf_http_res_request_id"])
+	assert.Contains(t, response, "tf_http_duration_ms")
+	assert.Equal(t, "***", response["tf_http_res_headers"].(map[string]interface{})["X-Subject-Token"])
+
+	for _, secret := range []string{"auth-token", "my-password", "c2VjcmV0", "provider-password", "subject-token", "generated-password"} {
+		assert.NotContains(t, logged, secret)
+	}
+}
+
+func TestUnitLoggingRoundTripper_levelInfo(t *testing.T) {
+	t.Setenv(logSubsystemEnv(httpLogSubsystem), "INFO")
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		received = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"server": {"id": "server-1"}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := newLoggingContext(tflogtest.RootLogger(context.Background(), &output), true)
+	rt := newLoggingRoundTripper(ctx, http.DefaultTransport, true)
+
+	body := io.NopCloser(strings.NewReader(`{"server": {"name": "instance_1"}}`))
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/v2/servers", body)
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := rt.RoundTrip(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// The bodies are not buffered, as nothing is logged at INFO.
+	assert.Equal(t, body, req.Body)
+	assert.Equal(t, `{"server": {"name": "instance_1"}}`, received)
+	assert.Empty(t, output.String())
+}
+
+func TestUnitRedactLogJSON(t *testing.T) {
+	body := `{"auth": {"identity": {"password": {"user": {"name": "user", "password": "p"}}}}, "keypairs": [{"private_key": "k"}], "secret": null}`
+	assert.Equal(t,
+		`{"auth":{"identity":{"password":"***"}},"keypairs":[{"private_key":"***"}],"secret":null}`,
+		redactLogJSON([]byte(body)))
+
+	body = `{"access": {"token": {"id": "t", "expires": "2024-01-01T00:00:00Z", "tenant": {"id": "p"}}}, "auth": {"token": "t"}}`
+	assert.Equal(t,
+		`{"access":{"token":{"expires":"2024-01-01T00:00:00Z","id":"***","tenant":{"id":"p"}}},"auth":{"token":"***"}}`,
+		redactLogJSON([]byte(body)))
+
+	assert.Equal(t, "(not logged: the body is not valid JSON)", redactLogJSON([]byte("password=p")))
+	assert.Equal(t, "", redactLogJSON(nil))
+}
@@ -0,0 +1,102 @@
+package nhncloud
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// internetGateway connects the VPCs of a project to an external network, as
+// returned by the NHN Cloud internet gateways API.
+type internetGateway struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	ExternalNetworkID string `json:"external_network_id"`
+	RoutingtableID    string `json:"routingtable_id"`
+	State             string `json:"state"`
+	MigrateStatus     string `json:"migrate_status"`
+	TenantID          string `json:"tenant_id"`
+	CreateTime        string `json:"create_time"`
+}
+
+type internetGatewayCreateOpts struct {
+	Name              string `json:"name,omitempty"`
+	ExternalNetworkID string `json:"external_network_id" required:"true"`
+}
+
+// internetGatewayListOpts filters the gateways returned by
+// internetGatewayList.
+type internetGatewayListOpts struct {
+	ID                string `q:"id"`
+	Name              string `q:"name"`
+	ExternalNetworkID string `q:"external_network_id"`
+	State             string `q:"state"`
+}
+
+func internetGatewayCreate(client *gophercloud.ServiceClient, opts internetGatewayCreateOpts) (*internetGateway, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "internetgateway")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		InternetGateway internetGateway `json:"internetgateway"`
+	}
+	_, err = client.Post(client.ServiceURL("internetgateways"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.InternetGateway, nil
+}
+
+func internetGatewayGet(client *gophercloud.ServiceClient, id string) (*internetGateway, error) {
+	var r struct {
+		InternetGateway internetGateway `json:"internetgateway"`
+	}
+	_, err := client.Get(client.ServiceURL("internetgateways", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.InternetGateway, nil
+}
+
+func internetGatewayList(client *gophercloud.ServiceClient, opts internetGatewayListOpts) ([]internetGateway, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		InternetGateways []internetGateway `json:"internetgateways"`
+	}
+	_, err = client.Get(client.ServiceURL("internetgateways")+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.InternetGateways, nil
+}
+
+func internetGatewayDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("internetgateways", id), nil)
+
+	return err
+}
+
+func networkingInternetGatewayV2StateRefreshFunc(client *gophercloud.ServiceClient, gatewayID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		gateway, err := internetGatewayGet(client, gatewayID)
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return gateway, "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+
+		return gateway, gateway.State, nil
+	}
+}
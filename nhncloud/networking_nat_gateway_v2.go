@@ -0,0 +1,130 @@
+package nhncloud
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// natGateway lets the instances of a VPC reach the internet through a
+// floating IP, as returned by the NHN Cloud NAT gateways API.
+type natGateway struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	VPCID        string `json:"vpc_id"`
+	SubnetID     string `json:"subnet_id"`
+	FloatingIPID string `json:"floatingip_id"`
+	State        string `json:"state"`
+	TenantID     string `json:"tenant_id"`
+	CreateTime   string `json:"create_time"`
+}
+
+type natGatewayCreateOpts struct {
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	VPCID        string `json:"vpc_id" required:"true"`
+	SubnetID     string `json:"subnet_id" required:"true"`
+	FloatingIPID string `json:"floatingip_id,omitempty"`
+}
+
+type natGatewayUpdateOpts struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// natGatewayListOpts filters the gateways returned by natGatewayList.
+type natGatewayListOpts struct {
+	ID       string `q:"id"`
+	Name     string `q:"name"`
+	VPCID    string `q:"vpc_id"`
+	SubnetID string `q:"subnet_id"`
+	State    string `q:"state"`
+}
+
+func natGatewayCreate(client *gophercloud.ServiceClient, opts natGatewayCreateOpts) (*natGateway, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "natgateway")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		NATGateway natGateway `json:"natgateway"`
+	}
+	_, err = client.Post(client.ServiceURL("natgateways"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.NATGateway, nil
+}
+
+func natGatewayGet(client *gophercloud.ServiceClient, id string) (*natGateway, error) {
+	var r struct {
+		NATGateway natGateway `json:"natgateway"`
+	}
+	_, err := client.Get(client.ServiceURL("natgateways", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.NATGateway, nil
+}
+
+func natGatewayList(client *gophercloud.ServiceClient, opts natGatewayListOpts) ([]natGateway, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		NATGateways []natGateway `json:"natgateways"`
+	}
+	_, err = client.Get(client.ServiceURL("natgateways")+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.NATGateways, nil
+}
+
+func natGatewayUpdate(client *gophercloud.ServiceClient, id string, opts natGatewayUpdateOpts) (*natGateway, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "natgateway")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		NATGateway natGateway `json:"natgateway"`
+	}
+	_, err = client.Put(client.ServiceURL("natgateways", id), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.NATGateway, nil
+}
+
+func natGatewayDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("natgateways", id), nil)
+
+	return err
+}
+
+func networkingNATGatewayV2StateRefreshFunc(client *gophercloud.ServiceClient, gatewayID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		gateway, err := natGatewayGet(client, gatewayID)
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return gateway, "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+
+		return gateway, gateway.State, nil
+	}
+}
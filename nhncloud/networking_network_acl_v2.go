@@ -0,0 +1,263 @@
+package nhncloud
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// networkACL is a stateless access control list for VPC subnets, as
+// returned by the NHN Cloud ACL API.
+type networkACL struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	TenantID    string `json:"tenant_id"`
+	CreateTime  string `json:"create_time"`
+}
+
+type networkACLCreateOpts struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type networkACLUpdateOpts struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// networkACLRule is a rule of an ACL. Rules are evaluated by ascending
+// OrderNum and the first matching rule applies. A nil Protocol or port range
+// matches any protocol or port.
+type networkACLRule struct {
+	ID              string  `json:"id"`
+	ACLID           string  `json:"acl_id"`
+	Description     string  `json:"description"`
+	Protocol        *string `json:"protocol"`
+	Ethertype       string  `json:"ethertype"`
+	SrcIP           string  `json:"src_ip"`
+	DstIP           string  `json:"dst_ip"`
+	SrcPortRangeMin *int    `json:"src_port_range_min"`
+	SrcPortRangeMax *int    `json:"src_port_range_max"`
+	DstPortRangeMin *int    `json:"dst_port_range_min"`
+	DstPortRangeMax *int    `json:"dst_port_range_max"`
+	Policy          string  `json:"policy"`
+	OrderNum        int     `json:"order_num"`
+	TenantID        string  `json:"tenant_id"`
+}
+
+// networkACLRuleOpts are the options to create or update a rule. The fields
+// without omitempty are always sent, so that null clears them on update.
+type networkACLRuleOpts struct {
+	ACLID           string  `json:"acl_id,omitempty"`
+	Ethertype       string  `json:"ethertype,omitempty"`
+	Description     string  `json:"description"`
+	Protocol        *string `json:"protocol"`
+	SrcIP           string  `json:"src_ip"`
+	DstIP           string  `json:"dst_ip"`
+	SrcPortRangeMin *int    `json:"src_port_range_min"`
+	SrcPortRangeMax *int    `json:"src_port_range_max"`
+	DstPortRangeMin *int    `json:"dst_port_range_min"`
+	DstPortRangeMax *int    `json:"dst_port_range_max"`
+	Policy          string  `json:"policy" required:"true"`
+	OrderNum        int     `json:"order_num" required:"true"`
+}
+
+// networkACLBinding applies an ACL to a subnet.
+type networkACLBinding struct {
+	ID       string `json:"id"`
+	ACLID    string `json:"acl_id"`
+	SubnetID string `json:"subnet_id"`
+	TenantID string `json:"tenant_id"`
+}
+
+type networkACLBindingCreateOpts struct {
+	ACLID    string `json:"acl_id" required:"true"`
+	SubnetID string `json:"subnet_id" required:"true"`
+}
+
+func networkACLCreate(client *gophercloud.ServiceClient, opts networkACLCreateOpts) (*networkACL, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "acl")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		ACL networkACL `json:"acl"`
+	}
+	_, err = client.Post(client.ServiceURL("acls"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ACL, nil
+}
+
+func networkACLGet(client *gophercloud.ServiceClient, id string) (*networkACL, error) {
+	var r struct {
+		ACL networkACL `json:"acl"`
+	}
+	_, err := client.Get(client.ServiceURL("acls", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ACL, nil
+}
+
+func networkACLUpdate(client *gophercloud.ServiceClient, id string, opts networkACLUpdateOpts) (*networkACL, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "acl")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		ACL networkACL `json:"acl"`
+	}
+	_, err = client.Put(client.ServiceURL("acls", id), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ACL, nil
+}
+
+func networkACLDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("acls", id), nil)
+
+	return err
+}
+
+func networkACLRuleCreate(client *gophercloud.ServiceClient, opts networkACLRuleOpts) (*networkACLRule, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "acl_rule")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Rule networkACLRule `json:"acl_rule"`
+	}
+	_, err = client.Post(client.ServiceURL("acl_rules"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Rule, nil
+}
+
+func networkACLRuleGet(client *gophercloud.ServiceClient, id string) (*networkACLRule, error) {
+	var r struct {
+		Rule networkACLRule `json:"acl_rule"`
+	}
+	_, err := client.Get(client.ServiceURL("acl_rules", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Rule, nil
+}
+
+func networkACLRuleUpdate(client *gophercloud.ServiceClient, id string, opts networkACLRuleOpts) (*networkACLRule, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "acl_rule")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Rule networkACLRule `json:"acl_rule"`
+	}
+	_, err = client.Put(client.ServiceURL("acl_rules", id), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Rule, nil
+}
+
+func networkACLRuleDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("acl_rules", id), nil)
+
+	return err
+}
+
+func networkACLBindingCreate(client *gophercloud.ServiceClient, opts networkACLBindingCreateOpts) (*networkACLBinding, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "aclbinding")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Binding networkACLBinding `json:"aclbinding"`
+	}
+	_, err = client.Post(client.ServiceURL("aclbindings"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Binding, nil
+}
+
+func networkACLBindingGet(client *gophercloud.ServiceClient, id string) (*networkACLBinding, error) {
+	var r struct {
+		Binding networkACLBinding `json:"aclbinding"`
+	}
+	_, err := client.Get(client.ServiceURL("aclbindings", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Binding, nil
+}
+
+func networkACLBindingDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("aclbindings", id), nil)
+
+	return err
+}
+
+// expandNetworkACLRuleOpts returns the options of a rule. Unset protocols and
+// ports are sent as null, which matches anything.
+func expandNetworkACLRuleOpts(d *schema.ResourceData) networkACLRuleOpts {
+	opts := networkACLRuleOpts{
+		Description: d.Get("description").(string),
+		SrcIP:       d.Get("src_ip").(string),
+		DstIP:       d.Get("dst_ip").(string),
+		Policy:      d.Get("policy").(string),
+		OrderNum:    d.Get("order_num").(int),
+	}
+
+	if v := d.Get("protocol").(string); v != "" {
+		opts.Protocol = &v
+	}
+
+	ports := map[string]**int{
+		"src_port_range_min": &opts.SrcPortRangeMin,
+		"src_port_range_max": &opts.SrcPortRangeMax,
+		"dst_port_range_min": &opts.DstPortRangeMin,
+		"dst_port_range_max": &opts.DstPortRangeMax,
+	}
+	for key, port := range ports {
+		if v := d.Get(key).(int); v != 0 {
+			*port = &v
+		}
+	}
+
+	return opts
+}
+
+func flattenNetworkACLRulePort(port *int) int {
+	if port == nil {
+		return 0
+	}
+
+	return *port
+}
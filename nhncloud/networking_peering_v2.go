@@ -0,0 +1,133 @@
+package nhncloud
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// peeringVPC is one side of a VPC peering.
+type peeringVPC struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	CIDRv4 string `json:"cidrv4"`
+}
+
+// peering is a VPC peering, as returned by the NHN Cloud peerings API.
+type peering struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	State       string     `json:"state"`
+	LocalVPCID  string     `json:"local_vpc_id"`
+	RemoteVPCID string     `json:"remote_vpc_id"`
+	LocalVPC    peeringVPC `json:"local_vpc"`
+	RemoteVPC   peeringVPC `json:"remote_vpc"`
+	TenantID    string     `json:"tenant_id"`
+	CreateTime  string     `json:"create_time"`
+}
+
+type peeringCreateOpts struct {
+	Name        string `json:"name,omitempty"`
+	LocalVPCID  string `json:"local_vpc_id" required:"true"`
+	RemoteVPCID string `json:"remote_vpc_id" required:"true"`
+}
+
+type peeringUpdateOpts struct {
+	Name *string `json:"name,omitempty"`
+}
+
+// peeringListOpts filters the peerings returned by peeringList.
+type peeringListOpts struct {
+	ID          string `q:"id"`
+	Name        string `q:"name"`
+	State       string `q:"state"`
+	LocalVPCID  string `q:"local_vpc_id"`
+	RemoteVPCID string `q:"remote_vpc_id"`
+}
+
+func peeringCreate(client *gophercloud.ServiceClient, opts peeringCreateOpts) (*peering, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "peering")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Peering peering `json:"peering"`
+	}
+	_, err = client.Post(client.ServiceURL("peerings"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Peering, nil
+}
+
+func peeringGet(client *gophercloud.ServiceClient, id string) (*peering, error) {
+	var r struct {
+		Peering peering `json:"peering"`
+	}
+	_, err := client.Get(client.ServiceURL("peerings", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Peering, nil
+}
+
+func peeringList(client *gophercloud.ServiceClient, opts peeringListOpts) ([]peering, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Peerings []peering `json:"peerings"`
+	}
+	_, err = client.Get(client.ServiceURL("peerings")+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Peerings, nil
+}
+
+func peeringUpdate(client *gophercloud.ServiceClient, id string, opts peeringUpdateOpts) (*peering, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "peering")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Peering peering `json:"peering"`
+	}
+	_, err = client.Put(client.ServiceURL("peerings", id), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Peering, nil
+}
+
+func peeringDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("peerings", id), nil)
+
+	return err
+}
+
+func networkingPeeringV2StateRefreshFunc(client *gophercloud.ServiceClient, peeringID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		p, err := peeringGet(client, peeringID)
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return p, "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+
+		return p, p.State, nil
+	}
+}
@@ -0,0 +1,241 @@
+package nhncloud
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// routingtableRouteGatewayTypeIP routes to an IP address in the VPC,
+	// such as an instance acting as a router.
+	routingtableRouteGatewayTypeIP = "ip"
+
+	// routingtableRouteGatewayTypeGateway routes to a gateway, a peering or
+	// a VPN by its ID.
+	routingtableRouteGatewayTypeGateway = "gateway"
+)
+
+// routingtableRoute is a static route of a routing table, as returned by the
+// NHN Cloud routes API.
+type routingtableRoute struct {
+	ID             string `json:"id"`
+	RoutingtableID string `json:"routingtable_id"`
+	CIDR           string `json:"cidr"`
+	Mask           int    `json:"mask"`
+	Gateway        string `json:"gateway"`
+	GatewayID      string `json:"gateway_id"`
+	TenantID       string `json:"tenant_id"`
+}
+
+// routingtableRouteCreateOpts are the options to create a static route. Only
+// one of Gateway and GatewayID is set.
+type routingtableRouteCreateOpts struct {
+	RoutingtableID string `json:"routingtable_id" required:"true"`
+	CIDR           string `json:"cidr" required:"true"`
+	Gateway        string `json:"gateway,omitempty"`
+	GatewayID      string `json:"gateway_id,omitempty"`
+}
+
+func (opts routingtableRouteCreateOpts) ToRoutingtableRouteCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "route")
+}
+
+func routingtableRouteCreate(client *gophercloud.ServiceClient, opts routingtableRouteCreateOpts) (*routingtableRoute, error) {
+	b, err := opts.ToRoutingtableRouteCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Route routingtableRoute `json:"route"`
+	}
+	_, err = client.Post(client.ServiceURL("routes"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Route, nil
+}
+
+func routingtableRouteGet(client *gophercloud.ServiceClient, id string) (*routingtableRoute, error) {
+	var r struct {
+		Route routingtableRoute `json:"route"`
+	}
+	_, err := client.Get(client.ServiceURL("routes", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.Route, nil
+}
+
+// routingtableRouteList returns the routes of a routing table.
+func routingtableRouteList(client *gophercloud.ServiceClient, routingtableID string) ([]routingtableRoute, error) {
+	url := client.ServiceURL("routes") + "?routingtable_id=" + routingtableID
+
+	var r struct {
+		Routes []routingtableRoute `json:"routes"`
+	}
+	_, err := client.Get(url, &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filtering is done by the API, but be safe against endpoints that
+	// ignore the query.
+	routes := make([]routingtableRoute, 0, len(r.Routes))
+	for _, route := range r.Routes {
+		if route.RoutingtableID == routingtableID {
+			routes = append(routes, route)
+		}
+	}
+
+	return routes, nil
+}
+
+func routingtableRouteDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("routes", id), nil)
+
+	return err
+}
+
+// routingtableRouteSchema returns the arguments describing the destination
+// and target of a route, shared by the route resource and the inline route
+// blocks of the routing table.
+func routingtableRouteSchema(forceNew bool) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cidr": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     forceNew,
+			ValidateFunc: validation.IsCIDRNetwork(0, 32),
+		},
+
+		"gateway_type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: forceNew,
+			ValidateFunc: validation.StringInSlice([]string{
+				routingtableRouteGatewayTypeIP, routingtableRouteGatewayTypeGateway,
+			}, false),
+		},
+
+		"gateway_target": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: forceNew,
+		},
+	}
+}
+
+func expandRoutingtableRouteCreateOpts(routingtableID, cidr, gatewayType, gatewayTarget string) routingtableRouteCreateOpts {
+	opts := routingtableRouteCreateOpts{
+		RoutingtableID: routingtableID,
+		CIDR:           cidr,
+	}
+
+	if gatewayType == routingtableRouteGatewayTypeIP {
+		opts.Gateway = gatewayTarget
+	} else {
+		opts.GatewayID = gatewayTarget
+	}
+
+	return opts
+}
+
+// flattenRoutingtableRouteGateway returns the gateway type and target of a
+// route as reported by the API.
+func flattenRoutingtableRouteGateway(route routingtableRoute) (string, string) {
+	if route.GatewayID != "" {
+		return routingtableRouteGatewayTypeGateway, route.GatewayID
+	}
+
+	return routingtableRouteGatewayTypeIP, route.Gateway
+}
+
+// filterRoutingtableRoutes returns the routes matching the given inline route
+// blocks. The other routes of the routing table, such as the system routes,
+// the routes of an attached gateway or nhncloud_networking_routingtable_route_v2,
+// are left alone by the inline blocks.
+func filterRoutingtableRoutes(routes []routingtableRoute, managed *schema.Set) []routingtableRoute {
+	result := make([]routingtableRoute, 0, len(routes))
+	for _, route := range routes {
+		gatewayType, gatewayTarget := flattenRoutingtableRouteGateway(route)
+		for _, raw := range managed.List() {
+			r := raw.(map[string]interface{})
+			if route.CIDR == r["cidr"].(string) && gatewayType == r["gateway_type"].(string) && gatewayTarget == r["gateway_target"].(string) {
+				result = append(result, route)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func flattenRoutingtableRoutes(routes []routingtableRoute) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(routes))
+	for _, route := range routes {
+		gatewayType, gatewayTarget := flattenRoutingtableRouteGateway(route)
+		result = append(result, map[string]interface{}{
+			"cidr":           route.CIDR,
+			"gateway_type":   gatewayType,
+			"gateway_target": gatewayTarget,
+		})
+	}
+
+	return result
+}
+
+// updateRoutingtableRoutes makes the routes of a routing table match the
+// inline route blocks, deleting the routes that were removed and creating the
+// ones that were added.
+func updateRoutingtableRoutes(client *gophercloud.ServiceClient, routingtableID string, oldRoutes, newRoutes *schema.Set) error {
+	removed := oldRoutes.Difference(newRoutes)
+	added := newRoutes.Difference(oldRoutes)
+
+	if removed.Len() > 0 {
+		current, err := routingtableRouteList(client, routingtableID)
+		if err != nil {
+			return fmt.Errorf("Error listing routes of nhncloud_networking_routingtable_v2 %s: %s", routingtableID, err)
+		}
+
+		for _, raw := range removed.List() {
+			r := raw.(map[string]interface{})
+			cidr := r["cidr"].(string)
+			for _, route := range current {
+				// Only the route of the removed block is deleted, not other
+				// routes to the same CIDR through another gateway.
+				gatewayType, gatewayTarget := flattenRoutingtableRouteGateway(route)
+				if route.CIDR != cidr || gatewayType != r["gateway_type"].(string) || gatewayTarget != r["gateway_target"].(string) {
+					continue
+				}
+
+				log.Printf("[DEBUG] Deleting route %s to %s from nhncloud_networking_routingtable_v2 %s", route.ID, cidr, routingtableID)
+				if err := routingtableRouteDelete(client, route.ID); err != nil {
+					if _, ok := err.(gophercloud.ErrDefault404); !ok {
+						return fmt.Errorf("Error deleting route %s from nhncloud_networking_routingtable_v2 %s: %s", cidr, routingtableID, err)
+					}
+				}
+			}
+		}
+	}
+
+	for _, raw := range added.List() {
+		r := raw.(map[string]interface{})
+		opts := expandRoutingtableRouteCreateOpts(routingtableID, r["cidr"].(string), r["gateway_type"].(string), r["gateway_target"].(string))
+
+		log.Printf("[DEBUG] Adding route to nhncloud_networking_routingtable_v2 %s: %#v", routingtableID, opts)
+		if _, err := routingtableRouteCreate(client, opts); err != nil {
+			return fmt.Errorf("Error adding route %s to nhncloud_networking_routingtable_v2 %s: %s", opts.CIDR, routingtableID, err)
+		}
+	}
+
+	return nil
+}
@@ -1,12 +1,18 @@
 package nhncloud
 
 import (
+	"bytes"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/utils/terraform/hashcode"
 )
 
 // networkingSecgroupV2StateRefreshFuncDelete returns a special case resource.StateRefreshFunc to try to delete a secgroup.
@@ -42,3 +48,139 @@ func networkingSecgroupV2StateRefreshFuncDelete(networkingClient *gophercloud.Se
 		return r, "ACTIVE", nil
 	}
 }
+
+// networkingSecgroupV2RuleHash hashes an inline rule of
+// openstack_networking_secgroup_v2 on the fields that identify it, so that a
+// rule read from the API matches the configured rule it was created from.
+func networkingSecgroupV2RuleHash(v interface{}) int {
+	var buf bytes.Buffer
+	m := v.(map[string]interface{})
+	buf.WriteString(fmt.Sprintf("%s-", m["direction"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["ethertype"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["protocol"].(string)))
+	buf.WriteString(fmt.Sprintf("%d-", m["port_range_min"].(int)))
+	buf.WriteString(fmt.Sprintf("%d-", m["port_range_max"].(int)))
+	buf.WriteString(fmt.Sprintf("%s-", strings.ToLower(m["remote_ip_prefix"].(string))))
+	buf.WriteString(fmt.Sprintf("%s-", m["remote_group_id"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["description"].(string)))
+
+	return hashcode.String(buf.String())
+}
+
+func networkingSecgroupV2FlattenRules(sgRules []rules.SecGroupRule) []interface{} {
+	result := make([]interface{}, len(sgRules))
+	for i, rule := range sgRules {
+		result[i] = map[string]interface{}{
+			"id":               rule.ID,
+			"direction":        rule.Direction,
+			"ethertype":        rule.EtherType,
+			"protocol":         rule.Protocol,
+			"port_range_min":   rule.PortRangeMin,
+			"port_range_max":   rule.PortRangeMax,
+			"remote_ip_prefix": rule.RemoteIPPrefix,
+			"remote_group_id":  rule.RemoteGroupID,
+			"description":      rule.Description,
+		}
+	}
+
+	return result
+}
+
+func networkingSecgroupV2ExpandRuleCreateOpts(sgID string, m map[string]interface{}) (rules.CreateOpts, error) {
+	protocol := m["protocol"].(string)
+	portRangeMin := m["port_range_min"].(int)
+	portRangeMax := m["port_range_max"].(int)
+
+	if protocol == "" && (portRangeMin != 0 || portRangeMax != 0) {
+		return rules.CreateOpts{}, fmt.Errorf("A protocol must be specified when using port_range_min and port_range_max for a rule of openstack_networking_secgroup_v2")
+	}
+
+	direction, err := resourceNetworkingSecGroupRuleV2Direction(m["direction"].(string))
+	if err != nil {
+		return rules.CreateOpts{}, err
+	}
+
+	etherType, err := resourceNetworkingSecGroupRuleV2EtherType(m["ethertype"].(string))
+	if err != nil {
+		return rules.CreateOpts{}, err
+	}
+
+	opts := rules.CreateOpts{
+		Direction:      direction,
+		EtherType:      etherType,
+		SecGroupID:     sgID,
+		PortRangeMin:   portRangeMin,
+		PortRangeMax:   portRangeMax,
+		RemoteIPPrefix: m["remote_ip_prefix"].(string),
+		RemoteGroupID:  m["remote_group_id"].(string),
+		Description:    m["description"].(string),
+	}
+
+	if protocol != "" {
+		opts.Protocol, err = resourceNetworkingSecGroupRuleV2Protocol(protocol)
+		if err != nil {
+			return rules.CreateOpts{}, err
+		}
+	}
+
+	return opts, nil
+}
+
+// networkingSecgroupV2DeleteRules deletes the given rules of a secgroup.
+func networkingSecgroupV2DeleteRules(networkingClient *gophercloud.ServiceClient, sgID string, sgRules []rules.SecGroupRule) error {
+	for _, rule := range sgRules {
+		log.Printf("[DEBUG] Deleting rule %s of openstack_networking_secgroup_v2 %s", rule.ID, sgID)
+		if err := rules.Delete(networkingClient, rule.ID).ExtractErr(); err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				continue
+			}
+			return fmt.Errorf("Error deleting rule %s of openstack_networking_secgroup_v2 %s: %s", rule.ID, sgID, err)
+		}
+	}
+
+	return nil
+}
+
+// networkingSecgroupV2ReconcileRules makes the rules of a secgroup match the
+// desired set of inline rules. The current rules are read from the API
+// rather than from the state, so rules added out of band are removed as
+// well. Rules that are already present are left untouched.
+func networkingSecgroupV2ReconcileRules(networkingClient *gophercloud.ServiceClient, sgID string, desired *schema.Set) error {
+	sg, err := groups.Get(networkingClient, sgID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving openstack_networking_secgroup_v2 %s: %s", sgID, err)
+	}
+
+	current := make(map[int]bool)
+	var stale []rules.SecGroupRule
+	for i, raw := range networkingSecgroupV2FlattenRules(sg.Rules) {
+		hash := networkingSecgroupV2RuleHash(raw)
+		if desired.Contains(raw) && !current[hash] {
+			current[hash] = true
+			continue
+		}
+		stale = append(stale, sg.Rules[i])
+	}
+
+	if err := networkingSecgroupV2DeleteRules(networkingClient, sgID, stale); err != nil {
+		return err
+	}
+
+	for _, raw := range desired.List() {
+		if current[networkingSecgroupV2RuleHash(raw)] {
+			continue
+		}
+
+		opts, err := networkingSecgroupV2ExpandRuleCreateOpts(sgID, raw.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] openstack_networking_secgroup_v2 %s rule create options: %#v", sgID, opts)
+		if _, err := rules.Create(networkingClient, opts).Extract(); err != nil {
+			return fmt.Errorf("Error creating a rule for openstack_networking_secgroup_v2 %s: %s", sgID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package nhncloud
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// serviceGateway lets the instances of a VPC reach an NHN Cloud service,
+// such as Object Storage, without going through the internet. It is returned
+// by the NHN Cloud service gateways API.
+type serviceGateway struct {
+	ID                string `json:"id"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	VPCID             string `json:"vpc_id"`
+	SubnetID          string `json:"subnet_id"`
+	ServiceEndpointID string `json:"service_endpoint_id"`
+	IPAddress         string `json:"ip_address"`
+	PortID            string `json:"port_id"`
+	State             string `json:"state"`
+	TenantID          string `json:"tenant_id"`
+	CreateTime        string `json:"create_time"`
+}
+
+type serviceGatewayCreateOpts struct {
+	Name              string `json:"name,omitempty"`
+	Description       string `json:"description,omitempty"`
+	VPCID             string `json:"vpc_id" required:"true"`
+	SubnetID          string `json:"subnet_id" required:"true"`
+	ServiceEndpointID string `json:"service_endpoint_id" required:"true"`
+	IPAddress         string `json:"ip_address,omitempty"`
+}
+
+type serviceGatewayUpdateOpts struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// serviceGatewayListOpts filters the gateways returned by serviceGatewayList.
+type serviceGatewayListOpts struct {
+	ID                string `q:"id"`
+	Name              string `q:"name"`
+	VPCID             string `q:"vpc_id"`
+	SubnetID          string `q:"subnet_id"`
+	ServiceEndpointID string `q:"service_endpoint_id"`
+	State             string `q:"state"`
+}
+
+func serviceGatewayCreate(client *gophercloud.ServiceClient, opts serviceGatewayCreateOpts) (*serviceGateway, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "servicegateway")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		ServiceGateway serviceGateway `json:"servicegateway"`
+	}
+	_, err = client.Post(client.ServiceURL("servicegateways"), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ServiceGateway, nil
+}
+
+func serviceGatewayGet(client *gophercloud.ServiceClient, id string) (*serviceGateway, error) {
+	var r struct {
+		ServiceGateway serviceGateway `json:"servicegateway"`
+	}
+	_, err := client.Get(client.ServiceURL("servicegateways", id), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ServiceGateway, nil
+}
+
+func serviceGatewayList(client *gophercloud.ServiceClient, opts serviceGatewayListOpts) ([]serviceGateway, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		ServiceGateways []serviceGateway `json:"servicegateways"`
+	}
+	_, err = client.Get(client.ServiceURL("servicegateways")+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.ServiceGateways, nil
+}
+
+func serviceGatewayUpdate(client *gophercloud.ServiceClient, id string, opts serviceGatewayUpdateOpts) (*serviceGateway, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "servicegateway")
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		ServiceGateway serviceGateway `json:"servicegateway"`
+	}
+	_, err = client.Put(client.ServiceURL("servicegateways", id), b, &r, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.ServiceGateway, nil
+}
+
+func serviceGatewayDelete(client *gophercloud.ServiceClient, id string) error {
+	_, err := client.Delete(client.ServiceURL("servicegateways", id), nil)
+
+	return err
+}
+
+func networkingServiceGatewayV2StateRefreshFunc(client *gophercloud.ServiceClient, gatewayID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		gateway, err := serviceGatewayGet(client, gatewayID)
+		if err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				return gateway, "DELETED", nil
+			}
+
+			return nil, "", err
+		}
+
+		return gateway, gateway.State, nil
+	}
+}
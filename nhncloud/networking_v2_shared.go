@@ -13,12 +13,12 @@ func networkingV2ReadAttributesTags(d *schema.ResourceData, tags []string) {
 	expandObjectReadTags(d, tags)
 }
 
-func networkingV2UpdateAttributesTags(d *schema.ResourceData) []string {
-	return expandObjectUpdateTags(d)
+func networkingV2UpdateAttributesTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectUpdateTags(d, defaultTags)
 }
 
-func networkingV2AttributesTags(d *schema.ResourceData) []string {
-	return expandObjectTags(d)
+func networkingV2AttributesTags(d *schema.ResourceData, defaultTags []string) []string {
+	return expandObjectTags(d, defaultTags)
 }
 
 type neutronErrorWrap struct {
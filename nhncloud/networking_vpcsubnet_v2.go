@@ -1,9 +1,13 @@
 package nhncloud
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/networking/v2/vpcsubnets"
@@ -51,3 +55,250 @@ func networkingVPCSubnetV2StateRefreshFuncDelete(networkingClient *gophercloud.S
 		return s, "ACTIVE", nil
 	}
 }
+
+// vpcSubnetSummary is a subnet as returned by the NHN Cloud VPC subnet list
+// API.
+type vpcSubnetSummary struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	VPCID            string `json:"vpc_id"`
+	CIDR             string `json:"cidr"`
+	Gateway          string `json:"gateway"`
+	State            string `json:"state"`
+	Shared           bool   `json:"shared"`
+	AvailableIPCount int    `json:"available_ip_count"`
+	TenantID         string `json:"tenant_id"`
+	CreateTime       string `json:"create_time"`
+	Routingtable     struct {
+		ID           string `json:"id"`
+		Name         string `json:"name"`
+		DefaultTable bool   `json:"default_table"`
+		Explicit     bool   `json:"explicit"`
+		GatewayID    string `json:"gateway_id"`
+	} `json:"routingtable"`
+	VPC struct {
+		Name   string `json:"name"`
+		CIDRv4 string `json:"cidrv4"`
+	} `json:"vpc"`
+}
+
+// vpcSubnetListOpts filters the subnets returned by vpcSubnetList.
+type vpcSubnetListOpts struct {
+	VPCID    string `q:"vpc_id"`
+	TenantID string `q:"tenant_id"`
+	Name     string `q:"name"`
+}
+
+func vpcSubnetList(client *gophercloud.ServiceClient, opts vpcSubnetListOpts) ([]vpcSubnetSummary, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var r struct {
+		Subnets []vpcSubnetSummary `json:"vpcsubnets"`
+	}
+	_, err = client.Get(client.ServiceURL("vpcsubnets")+q.String(), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Subnets, nil
+}
+
+// NHN Cloud limits the size of VPCs and of their subnets.
+const (
+	vpcCIDRMinPrefix       = 8
+	vpcCIDRMaxPrefix       = 24
+	vpcSubnetCIDRMinPrefix = 8
+	vpcSubnetCIDRMaxPrefix = 28
+)
+
+// vpcCIDRLayout is the address range of a VPC and of its subnets, as
+// returned by the NHN Cloud VPC API.
+type vpcCIDRLayout struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	CIDRv4  string `json:"cidrv4"`
+	Subnets []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		CIDR string `json:"cidr"`
+	} `json:"subnets"`
+}
+
+func vpcGetCIDRLayout(client *gophercloud.ServiceClient, vpcID string) (*vpcCIDRLayout, error) {
+	var r struct {
+		VPC vpcCIDRLayout `json:"vpc"`
+	}
+	_, err := client.Get(client.ServiceURL("vpcs", vpcID), &r, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r.VPC, nil
+}
+
+// parseVPCCIDR parses an IPv4 CIDR that must be a network address with a
+// prefix length between minPrefix and maxPrefix.
+func parseVPCCIDR(cidr string, minPrefix, maxPrefix int) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid CIDR %s: %s", cidr, err)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("CIDR %s is not an IPv4 CIDR", cidr)
+	}
+	if network.String() != cidr {
+		return nil, fmt.Errorf("CIDR %s doesn't match its network address %s", cidr, network)
+	}
+
+	prefix, _ := network.Mask.Size()
+	if prefix < minPrefix || prefix > maxPrefix {
+		return nil, fmt.Errorf("CIDR %s must have a prefix length between /%d and /%d", cidr, minPrefix, maxPrefix)
+	}
+
+	return network, nil
+}
+
+func cidrContains(outer, inner *net.IPNet) bool {
+	outerPrefix, _ := outer.Mask.Size()
+	innerPrefix, _ := inner.Mask.Size()
+
+	return outerPrefix <= innerPrefix && outer.Contains(inner.IP)
+}
+
+func cidrOverlaps(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// networkingV2DiffClient returns the networking client of the region and
+// project of a planned resource.
+func networkingV2DiffClient(diff *schema.ResourceDiff, meta interface{}) (*gophercloud.ServiceClient, error) {
+	config := meta.(*Config)
+
+	region := config.Region
+	if v, ok := diff.GetOk("region"); ok {
+		region = v.(string)
+	}
+
+	config, err := config.ProjectConfig(diff.Get("project_id").(string), region)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := config.NetworkingV2Client(region)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	return client, nil
+}
+
+// networkingVPCV2CIDRCustomizeDiff checks cidrv4 at plan time. A changed
+// cidrv4 of an existing VPC must still contain all of its subnets.
+func networkingVPCV2CIDRCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	// Existing VPCs are only checked when cidrv4 changes, so that ones
+	// created or imported with a CIDR outside these rules still plan.
+	if !diff.NewValueKnown("cidrv4") || (diff.Id() != "" && !diff.HasChange("cidrv4")) {
+		return nil
+	}
+
+	cidr := diff.Get("cidrv4").(string)
+	network, err := parseVPCCIDR(cidr, vpcCIDRMinPrefix, vpcCIDRMaxPrefix)
+	if err != nil {
+		return fmt.Errorf("Invalid cidrv4 for nhncloud_networking_vpc_v2: %s", err)
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	client, err := networkingV2DiffClient(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	vpc, err := vpcGetCIDRLayout(client, diff.Id())
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return nil
+		}
+		return fmt.Errorf("Error getting nhncloud_networking_vpc_v2 %s: %s", diff.Id(), err)
+	}
+
+	for _, subnet := range vpc.Subnets {
+		_, subnetNetwork, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			continue
+		}
+		if !cidrContains(network, subnetNetwork) {
+			return fmt.Errorf("cidrv4 %s of nhncloud_networking_vpc_v2 %s does not contain subnet %s (%s) with cidr %s",
+				cidr, diff.Id(), subnet.Name, subnet.ID, subnet.CIDR)
+		}
+	}
+
+	return nil
+}
+
+// networkingVPCSubnetV2CIDRCustomizeDiff checks cidr at plan time. It must
+// lie inside the cidrv4 of the VPC and must not overlap the other subnets of
+// the VPC. The VPC is looked up unless it is yet to be created, in which case
+// resourceNetworkingVPCSubnetV2Create checks cidr before creating the subnet.
+func networkingVPCSubnetV2CIDRCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("cidr") || (diff.Id() != "" && !diff.HasChange("cidr") && !diff.HasChange("vpc_id")) {
+		return nil
+	}
+
+	cidr := diff.Get("cidr").(string)
+	network, err := parseVPCCIDR(cidr, vpcSubnetCIDRMinPrefix, vpcSubnetCIDRMaxPrefix)
+	if err != nil {
+		return fmt.Errorf("Invalid cidr for nhncloud_networking_vpcsubnet_v2: %s", err)
+	}
+
+	if !diff.NewValueKnown("vpc_id") {
+		return nil
+	}
+
+	client, err := networkingV2DiffClient(diff, meta)
+	if err != nil {
+		return err
+	}
+
+	return vpcSubnetV2CheckCIDR(client, diff.Get("vpc_id").(string), diff.Id(), cidr, network)
+}
+
+// vpcSubnetV2CheckCIDR checks that the cidr of a subnet lies inside the
+// cidrv4 of its VPC and does not overlap the other subnets of the VPC.
+func vpcSubnetV2CheckCIDR(client *gophercloud.ServiceClient, vpcID, subnetID, cidr string, network *net.IPNet) error {
+	vpc, err := vpcGetCIDRLayout(client, vpcID)
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); ok {
+			return fmt.Errorf("VPC %s of nhncloud_networking_vpcsubnet_v2 could not be found", vpcID)
+		}
+		return fmt.Errorf("Error getting nhncloud_networking_vpc_v2 %s: %s", vpcID, err)
+	}
+
+	if _, vpcNetwork, err := net.ParseCIDR(vpc.CIDRv4); err == nil && !cidrContains(vpcNetwork, network) {
+		return fmt.Errorf("cidr %s of nhncloud_networking_vpcsubnet_v2 is outside cidrv4 %s of VPC %s (%s)",
+			cidr, vpc.CIDRv4, vpc.Name, vpc.ID)
+	}
+
+	for _, subnet := range vpc.Subnets {
+		// The subnet itself is replaced when its cidr changes.
+		if subnet.ID == subnetID {
+			continue
+		}
+
+		_, subnetNetwork, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			continue
+		}
+		if cidrOverlaps(network, subnetNetwork) {
+			return fmt.Errorf("cidr %s of nhncloud_networking_vpcsubnet_v2 overlaps subnet %s (%s) with cidr %s",
+				cidr, subnet.Name, subnet.ID, subnet.CIDR)
+		}
+	}
+
+	return nil
+}
@@ -89,3 +89,27 @@ func orchestrationStackV1StateRefreshFunc(client *gophercloud.ServiceClient, sta
 		return stack, stack.Status, nil
 	}
 }
+
+// orchestrationStackV1Tags returns the configured stack tags merged with the
+// provider default tags.
+func orchestrationStackV1Tags(d *schema.ResourceData, defaultTags []string) []string {
+	tags := expandToStringSlice(d.Get("tags").([]interface{}))
+
+	return mergeDefaultTags(tags, defaultTags)
+}
+
+// orchestrationStackV1ReadTags drops the provider default tags that are not
+// part of the configured tags, so that they only show up in all_tags.
+func orchestrationStackV1ReadTags(d *schema.ResourceData, tags []string, defaultTags []string) []string {
+	configuredTags := expandToStringSlice(d.Get("tags").([]interface{}))
+
+	var result = []string{}
+	for _, tag := range tags {
+		if strSliceContains(defaultTags, tag) && !strSliceContains(configuredTags, tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+
+	return result
+}
@@ -2,14 +2,21 @@ package nhncloud
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	tokens2 "github.com/gophercloud/gophercloud/openstack/identity/v2/tokens"
+	tokens3 "github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	osClient "github.com/gophercloud/utils/client"
 	"github.com/gophercloud/utils/terraform/auth"
 	"github.com/gophercloud/utils/terraform/mutexkv"
 )
@@ -18,6 +25,20 @@ import (
 // Config struct.
 type Config struct {
 	auth.Config
+
+	// DefaultTags are merged into the tags of every resource that supports
+	// tags.
+	DefaultTags []string
+
+	// projects caches the configurations scoped to the projects set by the
+	// project_id resource argument, keyed by project and region.
+	projects map[projectScope]*Config
+}
+
+// projectScope is the key of a project-scoped configuration.
+type projectScope struct {
+	projectID string
+	region    string
 }
 
 // Provider returns a schema.Provider for NHN Cloud.
@@ -243,6 +264,50 @@ func Provider() *schema.Provider {
 				Description: descriptions["max_retries"],
 			},
 
+			"retry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["retry"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_attempts": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"base_backoff": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "1s",
+						},
+
+						"max_backoff": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "30s",
+						},
+
+						"jitter": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"retryable_status_codes": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(400, 599),
+							},
+						},
+					},
+				},
+			},
+
 			"endpoint_overrides": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -262,6 +327,22 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["enable_logging"],
 			},
+
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["default_tags"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -274,6 +355,8 @@ func Provider() *schema.Provider {
 			"nhncloud_compute_aggregate_v2":                     dataSourceComputeAggregateV2(),
 			"nhncloud_compute_availability_zones_v2":            dataSourceComputeAvailabilityZonesV2(),
 			"nhncloud_compute_instance_v2":                      dataSourceComputeInstanceV2(),
+			"nhncloud_compute_instance_console_output_v2":       dataSourceComputeInstanceConsoleOutputV2(),
+			"nhncloud_compute_instance_password_v2":             dataSourceComputeInstancePasswordV2(),
 			"nhncloud_compute_flavor_v2":                        dataSourceComputeFlavorV2(),
 			"nhncloud_compute_hypervisor_v2":                    dataSourceComputeHypervisorV2(),
 			"nhncloud_compute_keypair_v2":                       dataSourceComputeKeypairV2(),
@@ -282,6 +365,7 @@ func Provider() *schema.Provider {
 			"nhncloud_kubernetes_nodegroup_v1":                  dataSourceKubernetesNodeGroupV1(),
 			"nhncloud_kubernetes_cluster_v1":                    dataSourceKubernetesCluster(),
 			"nhncloud_kubernetes_clustertemplate_v1":            dataSourceKubernetesClusterTemplateV1(),
+			"nhncloud_kubernetes_addons_v1":                     dataSourceKubernetesAddonsV1(),
 			"nhncloud_dns_zone_v2":                              dataSourceDNSZoneV2(),
 			"nhncloud_fw_policy_v1":                             dataSourceFWPolicyV1(),
 			"nhncloud_identity_role_v3":                         dataSourceIdentityRoleV3(),
@@ -303,6 +387,7 @@ func Provider() *schema.Provider {
 			"nhncloud_networking_quota_v2":                      dataSourceNetworkingQuotaV2(),
 			"nhncloud_networking_subnet_v2":                     dataSourceNetworkingSubnetV2(),
 			"nhncloud_networking_vpcsubnet_v2":                  dataSourceNetworkingVPCSubnetV2(),
+			"nhncloud_networking_vpcsubnets_v2":                 dataSourceNetworkingVPCSubnetsV2(),
 			"nhncloud_networking_subnet_ids_v2":                 dataSourceNetworkingSubnetIDsV2(),
 			"nhncloud_networking_secgroup_v2":                   dataSourceNetworkingSecGroupV2(),
 			"nhncloud_networking_subnetpool_v2":                 dataSourceNetworkingSubnetPoolV2(),
@@ -318,24 +403,34 @@ func Provider() *schema.Provider {
 			"nhncloud_keymanager_secret_v1":                     dataSourceKeyManagerSecretV1(),
 			"nhncloud_keymanager_container_v1":                  dataSourceKeyManagerContainerV1(),
 			"nhncloud_networking_routingtable_v2":               dataSourceNetworkingRoutingtableV2(),
+			"nhncloud_networking_peering_v2":                    dataSourceNetworkingPeeringV2(),
+			"nhncloud_networking_internet_gateway_v2":           dataSourceNetworkingInternetGatewayV2(),
+			"nhncloud_networking_nat_gateway_v2":                dataSourceNetworkingNATGatewayV2(),
+			"nhncloud_networking_service_gateway_v2":            dataSourceNetworkingServiceGatewayV2(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
+			"nhncloud_blockstorage_backup_v3":                    resourceBlockStorageBackupV3(),
+			"nhncloud_blockstorage_backup_restore_v3":            resourceBlockStorageBackupRestoreV3(),
 			"nhncloud_blockstorage_qos_association_v3":           resourceBlockStorageQosAssociationV3(),
 			"nhncloud_blockstorage_qos_v3":                       resourceBlockStorageQosV3(),
 			"nhncloud_blockstorage_quotaset_v2":                  resourceBlockStorageQuotasetV2(),
 			"nhncloud_blockstorage_quotaset_v3":                  resourceBlockStorageQuotasetV3(),
+			"nhncloud_blockstorage_retention_policy_v3":          resourceBlockStorageRetentionPolicyV3(),
+			"nhncloud_blockstorage_snapshot_v3":                  resourceBlockStorageSnapshotV3(),
 			"nhncloud_blockstorage_volume_v1":                    resourceBlockStorageVolumeV1(),
 			"nhncloud_blockstorage_volume_v2":                    resourceBlockStorageVolumeV2(),
 			"nhncloud_blockstorage_volume_v3":                    resourceBlockStorageVolumeV3(),
 			"nhncloud_blockstorage_volume_attach_v2":             resourceBlockStorageVolumeAttachV2(),
 			"nhncloud_blockstorage_volume_attach_v3":             resourceBlockStorageVolumeAttachV3(),
+			"nhncloud_blockstorage_volume_revert_v3":             resourceBlockStorageVolumeRevertV3(),
 			"nhncloud_blockstorage_volume_type_access_v3":        resourceBlockstorageVolumeTypeAccessV3(),
 			"nhncloud_blockstorage_volume_type_v3":               resourceBlockStorageVolumeTypeV3(),
 			"nhncloud_compute_aggregate_v2":                      resourceComputeAggregateV2(),
 			"nhncloud_compute_flavor_v2":                         resourceComputeFlavorV2(),
 			"nhncloud_compute_flavor_access_v2":                  resourceComputeFlavorAccessV2(),
 			"nhncloud_compute_instance_v2":                       resourceComputeInstanceV2(),
+			"nhncloud_compute_instance_image_v2":                 resourceComputeInstanceImageV2(),
 			"nhncloud_compute_interface_attach_v2":               resourceComputeInterfaceAttachV2(),
 			"nhncloud_compute_keypair_v2":                        resourceComputeKeypairV2(),
 			"nhncloud_compute_secgroup_v2":                       resourceComputeSecGroupV2(),
@@ -346,9 +441,11 @@ func Provider() *schema.Provider {
 			"nhncloud_compute_volume_attach_v2":                  resourceComputeVolumeAttachV2(),
 			"nhncloud_kubernetes_cluster_v1":                     resourceKubernetesClusterV1(),
 			"nhncloud_kubernetes_cluster_resize_v1":              resourceKubernetesClusterResizeV1(),
+			"nhncloud_kubernetes_cluster_upgrade_v1":             resourceKubernetesClusterUpgradeV1(),
 			"nhncloud_kubernetes_nodegroup_v1":                   resourceKubernetesNodeGroupV1(),
 			"nhncloud_kubernetes_nodegroup_upgrade_v1":           resourceKubernetesNodegroupUpgradeV1(),
 			"nhncloud_kubernetes_clustertemplate_v1":             resourceKubernetesClusterTemplateV1(),
+			"nhncloud_kubernetes_addon_v1":                       resourceKubernetesAddonV1(),
 			"nhncloud_db_instance_v1":                            resourceDatabaseInstanceV1(),
 			"nhncloud_db_user_v1":                                resourceDatabaseUserV1(),
 			"nhncloud_db_configuration_v1":                       resourceDatabaseConfigurationV1(),
@@ -428,17 +525,26 @@ func Provider() *schema.Provider {
 			"nhncloud_keymanager_order_v1":                       resourceKeyManagerOrderV1(),
 			"nhncloud_networking_routingtable_v2":                resourceNetworkingRoutingtableV2(),
 			"nhncloud_networking_routingtable_attach_gateway_v2": resourceNetworkingRoutingtableAttachGatewayV2(),
+			"nhncloud_networking_routingtable_route_v2":          resourceNetworkingRoutingtableRouteV2(),
+			"nhncloud_networking_peering_v2":                     resourceNetworkingPeeringV2(),
+			"nhncloud_networking_peering_route_v2":               resourceNetworkingPeeringRouteV2(),
+			"nhncloud_networking_internet_gateway_v2":            resourceNetworkingInternetGatewayV2(),
+			"nhncloud_networking_nat_gateway_v2":                 resourceNetworkingNATGatewayV2(),
+			"nhncloud_networking_service_gateway_v2":             resourceNetworkingServiceGatewayV2(),
+			"nhncloud_networking_network_acl_v2":                 resourceNetworkingNetworkACLV2(),
+			"nhncloud_networking_network_acl_rule_v2":            resourceNetworkingNetworkACLRuleV2(),
+			"nhncloud_networking_network_acl_binding_v2":         resourceNetworkingNetworkACLBindingV2(),
 		},
 	}
 
-	provider.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		terraformVersion := provider.TerraformVersion
 		if terraformVersion == "" {
 			// Terraform 0.12 introduced this field to the protocol
 			// We can therefore assume that if it's missing it's 0.10 or 0.11
 			terraformVersion = "0.11+compatible"
 		}
-		return configureProvider(d, terraformVersion)
+		return configureProvider(ctx, d, terraformVersion)
 	}
 
 	return provider
@@ -519,11 +625,15 @@ func init() {
 
 		"max_retries": "How many times HTTP connection should be retried until giving up.",
 
-		"enable_logging": "Outputs very verbose logs with all calls made to and responses from OpenStack",
+		"retry": "Retry policy for API calls that are throttled or fail with a server error.",
+
+		"enable_logging": "Logs all calls made to and responses from NHN Cloud through the provider `http` and `auth` log subsystems, with secrets redacted.",
+
+		"default_tags": "Tags that are added to every resource that supports tags, in addition to the resource's own `tags`.",
 	}
 }
 
-func configureProvider(d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
+func configureProvider(ctx context.Context, d *schema.ResourceData, terraformVersion string) (interface{}, diag.Diagnostics) {
 	enableLogging := d.Get("enable_logging").(bool)
 	if !enableLogging {
 		// enforce logging (similar to OS_DEBUG) when TF_LOG is 'DEBUG' or 'TRACE'
@@ -539,7 +649,7 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 	}
 
 	config := Config{
-		auth.Config{
+		Config: auth.Config{
 			CACertFile:                  d.Get("cacert_file").(string),
 			ClientCertFile:              d.Get("cert").(string),
 			ClientKeyFile:               d.Get("key").(string),
@@ -576,6 +686,7 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 			MutexKV:                     mutexkv.NewMutexKV(),
 			EnableLogger:                enableLogging,
 		},
+		DefaultTags: expandProviderDefaultTags(d.Get("default_tags").([]interface{})),
 	}
 
 	v, ok := d.GetOk("insecure")
@@ -584,9 +695,183 @@ func configureProvider(d *schema.ResourceData, terraformVersion string) (interfa
 		config.Insecure = &insecure
 	}
 
+	retry, err := expandProviderRetryPolicy(d.Get("retry").([]interface{}))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	// Authenticate after the transports below are installed, so that the
+	// token request is logged and retried like any other request.
+	delayedAuth := config.DelayedAuth
+	config.DelayedAuth = true
+
 	if err := config.LoadAndValidate(); err != nil {
 		return nil, diag.FromErr(err)
 	}
 
+	if rt, ok := config.OsClient.HTTPClient.Transport.(*osClient.RoundTripper); ok {
+		// The gophercloud logger dumps raw traffic, so requests are logged
+		// through tflog instead, with secrets redacted.
+		// The level of the tflog subsystems decides what is logged.
+		rt.Logger = nil
+		logCtx := newLoggingContext(ctx, config.EnableLogger, config.Password, config.Token, config.ApplicationCredentialSecret)
+		rt.Rt = newLoggingRoundTripper(logCtx, rt.Rt, config.EnableLogger)
+	}
+
+	if retry != nil {
+		config.OsClient.HTTPClient.Transport = newRetryRoundTripper(config.OsClient.HTTPClient.Transport, retry)
+	}
+
+	if !delayedAuth && !config.Swauth {
+		if err := config.Authenticate(); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	config.DelayedAuth = delayedAuth
+
 	return &config, nil
 }
+
+// expandProviderDefaultTags returns the tags of the provider default_tags
+// block.
+func expandProviderDefaultTags(v []interface{}) []string {
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+
+	tags := v[0].(map[string]interface{})["tags"].(*schema.Set).List()
+
+	return expandToStringSlice(tags)
+}
+
+// ProjectConfig returns a configuration whose clients are scoped to the given
+// project and region. The provider credentials are re-scoped once per
+// project, and the resulting configuration is cached per project and region.
+// The provider configuration itself is returned when projectID is empty or
+// matches the project that the provider is authenticated to.
+func (c *Config) ProjectConfig(projectID, region string) (*Config, error) {
+	if projectID == "" || projectID == c.TenantID {
+		return c, nil
+	}
+
+	if c.ApplicationCredentialID != "" || c.ApplicationCredentialName != "" {
+		return nil, fmt.Errorf("Error scoping NHN Cloud client to project %s: application credentials are bound to a single project", projectID)
+	}
+
+	if c.Swauth {
+		return nil, fmt.Errorf("Error scoping NHN Cloud client to project %s: not supported with swauth", projectID)
+	}
+
+	if err := c.Authenticate(); err != nil {
+		return nil, err
+	}
+
+	// The provider client is written under the auth lock while it
+	// authenticates, so it is only read under it too.
+	c.MutexKV.Lock("auth")
+	base := c.Config
+	authProjectID := providerAuthProjectID(c.OsClient)
+	c.MutexKV.Unlock("auth")
+
+	// The provider may only be configured with tenant_name.
+	if projectID == authProjectID {
+		return c, nil
+	}
+
+	c.MutexKV.Lock("projects")
+	defer c.MutexKV.Unlock("projects")
+
+	key := projectScope{projectID: projectID, region: c.DetermineRegion(region)}
+	if pc, ok := c.projects[key]; ok {
+		return pc, nil
+	}
+
+	// Reuse the token of the project for any other region.
+	var client *gophercloud.ProviderClient
+	var authOpts *gophercloud.AuthOptions
+	for scope, pc := range c.projects {
+		if scope.projectID == projectID {
+			client, authOpts = pc.OsClient, pc.AuthOpts
+			break
+		}
+	}
+
+	if client == nil {
+		var err error
+		client, authOpts, err = newProjectClient(base, projectID)
+		if err != nil {
+			return nil, fmt.Errorf("Error scoping NHN Cloud client to project %s: %s", projectID, err)
+		}
+	}
+
+	pc := &Config{
+		Config:      base,
+		DefaultTags: c.DefaultTags,
+	}
+	pc.OsClient = client
+	pc.AuthOpts = authOpts
+	pc.TenantID = projectID
+	pc.TenantName = ""
+	pc.Region = key.region
+	pc.DelayedAuth = false
+
+	if c.projects == nil {
+		c.projects = make(map[projectScope]*Config)
+	}
+	c.projects[key] = pc
+
+	return pc, nil
+}
+
+// providerAuthProjectID returns the ID of the project that the token of the
+// provider client is scoped to, or an empty string if it is not known.
+func providerAuthProjectID(client *gophercloud.ProviderClient) string {
+	if client == nil {
+		return ""
+	}
+
+	switch result := client.GetAuthResult().(type) {
+	case tokens3.CreateResult:
+		if project, err := result.ExtractProject(); err == nil && project != nil {
+			return project.ID
+		}
+	case tokens3.GetResult:
+		if project, err := result.ExtractProject(); err == nil && project != nil {
+			return project.ID
+		}
+	case tokens2.CreateResult:
+		if token, err := result.ExtractToken(); err == nil {
+			return token.Tenant.ID
+		}
+	}
+
+	return ""
+}
+
+// newProjectClient authenticates the provider credentials scoped to projectID.
+// The HTTP client, and so the TLS settings, retries and logging, is shared
+// with the provider client.
+func newProjectClient(c auth.Config, projectID string) (*gophercloud.ProviderClient, *gophercloud.AuthOptions, error) {
+	authOpts := *c.AuthOpts
+	authOpts.TenantID = projectID
+	authOpts.TenantName = ""
+	authOpts.Scope = &gophercloud.AuthScope{ProjectID: projectID}
+
+	client, err := openstack.NewClient(authOpts.IdentityEndpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client.Context = c.OsClient.Context
+	client.HTTPClient = c.OsClient.HTTPClient
+	client.UserAgent = c.OsClient.UserAgent
+	client.MaxBackoffRetries = c.OsClient.MaxBackoffRetries
+	client.RetryBackoffFunc = c.OsClient.RetryBackoffFunc
+
+	log.Printf("[DEBUG] Authenticating NHN Cloud client scoped to project %s", projectID)
+	if err := openstack.Authenticate(client, authOpts); err != nil {
+		return nil, nil, err
+	}
+
+	return client, &authOpts, nil
+}
@@ -7,14 +7,17 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
 	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/pathorcontents"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/utils/terraform/auth"
 	"github.com/gophercloud/utils/terraform/mutexkv"
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -319,6 +322,143 @@ func TestUnitProvider(t *testing.T) {
 	}
 }
 
+// testUnitMockCloud starts a fake NHN Cloud for resource.UnitTest. It returns
+// the server, provider factories with a fresh provider and a provider block
+// that points the provider at the server.
+func testUnitMockCloud(t *testing.T) (*mockcloud.Server, map[string]func() (*schema.Provider, error), string) {
+	server := mockcloud.NewServer()
+	t.Cleanup(server.Close)
+
+	providers := map[string]func() (*schema.Provider, error){
+		"nhncloud": func() (*schema.Provider, error) {
+			return Provider(), nil
+		},
+	}
+
+	return server, providers, testUnitMockCloudProviderConfig(server, "")
+}
+
+// testUnitMockCloudProviderConfig returns a provider block that points the
+// provider at server, with extra appended to its arguments.
+func testUnitMockCloudProviderConfig(server *mockcloud.Server, extra string) string {
+	return fmt.Sprintf(`
+provider "nhncloud" {
+  auth_url  = "%s"
+  region    = "%s"
+  user_name = "%s"
+  password  = "%s"
+  tenant_id = "%s"
+%s
+}
+`, server.AuthURL(), server.Region, mockcloud.DefaultUserName, mockcloud.DefaultPassword, server.ProjectID, extra)
+}
+
+// testUnitCheckMockCloudDestroyed verifies that every resource of the given
+// type is gone from the fake NHN Cloud, where it is stored under kind.
+func testUnitCheckMockCloudDestroyed(server *mockcloud.Server, resourceType, kind string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+
+			if _, ok := server.Get(kind, rs.Primary.ID); ok {
+				return fmt.Errorf("%s %s still exists", resourceType, rs.Primary.ID)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testUnitCheckMockCloudAttr verifies a field of the object backing a
+// resource in the fake NHN Cloud.
+func testUnitCheckMockCloudAttr(server *mockcloud.Server, n, kind, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		obj, ok := server.Get(kind, rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("%s %s not found in the mock cloud", kind, rs.Primary.ID)
+		}
+
+		if actual := fmt.Sprint(obj[key]); actual != value {
+			return fmt.Errorf("Expected %s.%s to be %q, got %q", kind, key, value, actual)
+		}
+
+		return nil
+	}
+}
+
+func TestUnitConfigProjectConfig(t *testing.T) {
+	server := mockcloud.NewServer()
+	defer server.Close()
+
+	config := &Config{
+		Config: auth.Config{
+			IdentityEndpoint: server.AuthURL(),
+			Region:           server.Region,
+			Username:         mockcloud.DefaultUserName,
+			Password:         mockcloud.DefaultPassword,
+			TenantID:         server.ProjectID,
+			DomainName:       "Default",
+			AuthOpts:         &gophercloud.AuthOptions{Scope: &gophercloud.AuthScope{}},
+			MutexKV:          mutexkv.NewMutexKV(),
+		},
+	}
+	assert.NoError(t, config.LoadAndValidate())
+
+	pc, err := config.ProjectConfig("", "")
+	assert.NoError(t, err)
+	assert.Same(t, config, pc)
+
+	pc, err = config.ProjectConfig(server.ProjectID, "")
+	assert.NoError(t, err)
+	assert.Same(t, config, pc)
+
+	pc, err = config.ProjectConfig("other-project", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "other-project", pc.TenantID)
+	assert.Equal(t, server.Region, pc.Region)
+	assert.NotEqual(t, config.OsClient.Token(), pc.OsClient.Token())
+
+	cached, err := config.ProjectConfig("other-project", server.Region)
+	assert.NoError(t, err)
+	assert.Same(t, pc, cached)
+
+	otherRegion, err := config.ProjectConfig("other-project", "KR2")
+	assert.NoError(t, err)
+	assert.NotSame(t, pc, otherRegion)
+	assert.Same(t, pc.OsClient, otherRegion.OsClient)
+
+	config.ApplicationCredentialID = "credential"
+	_, err = config.ProjectConfig("another-project", "")
+	assert.Error(t, err)
+
+	// A provider configured with tenant_name only is scoped to the project
+	// ID that the token was issued for.
+	nameConfig := &Config{
+		Config: auth.Config{
+			IdentityEndpoint: server.AuthURL(),
+			Region:           server.Region,
+			Username:         mockcloud.DefaultUserName,
+			Password:         mockcloud.DefaultPassword,
+			TenantName:       "mock-project",
+			DomainName:       "Default",
+			AuthOpts:         &gophercloud.AuthOptions{Scope: &gophercloud.AuthScope{}},
+			MutexKV:          mutexkv.NewMutexKV(),
+		},
+	}
+	assert.NoError(t, nameConfig.LoadAndValidate())
+
+	pc, err = nameConfig.ProjectConfig(server.ProjectID, "")
+	assert.NoError(t, err)
+	assert.Same(t, nameConfig, pc)
+}
+
 // Steps for configuring OpenStack with SSL validation are here:
 // https://github.com/hashicorp/terraform/pull/6279#issuecomment-219020144
 func TestAccProvider_caCertFile(t *testing.T) {
@@ -478,7 +618,7 @@ func testAccAuthFromEnv() (*Config, error) {
 	}
 
 	config := Config{
-		auth.Config{
+		Config: auth.Config{
 			CACertFile:                  os.Getenv("OS_CACERT"),
 			ClientCertFile:              os.Getenv("OS_CERT"),
 			ClientKeyFile:               os.Getenv("OS_KEY"),
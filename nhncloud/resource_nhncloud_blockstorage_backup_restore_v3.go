@@ -0,0 +1,147 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+)
+
+// resourceBlockStorageBackupRestoreV3 restores a backup when it is created,
+// either to a new volume or over an existing one. The restored volume is not
+// managed by the resource, so deleting it only removes it from the state.
+func resourceBlockStorageBackupRestoreV3() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBlockStorageBackupRestoreV3Create,
+		ReadContext:   resourceBlockStorageBackupRestoreV3Read,
+		DeleteContext: resourceBlockStorageBackupRestoreV3Delete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"backup_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"volume_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"name"},
+			},
+
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"volume_id"},
+			},
+
+			"volume_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockStorageBackupRestoreV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	backupID := d.Get("backup_id").(string)
+	restoreOpts := backups.RestoreOpts{
+		VolumeID: d.Get("volume_id").(string),
+		Name:     d.Get("name").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_blockstorage_backup_restore_v3 %s restore options: %#v", backupID, restoreOpts)
+	r, err := backups.RestoreFromBackup(blockStorageClient, backupID, restoreOpts).Extract()
+	if err != nil {
+		return diag.Errorf("Error restoring nhncloud_blockstorage_backup_v3 %s: %s", backupID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", backupID, r.VolumeID))
+	d.Set("volume_id", r.VolumeID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "restoring-backup"},
+		Target:     []string{"available"},
+		Refresh:    blockStorageVolumeV3StateRefreshFunc(blockStorageClient, r.VolumeID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf(
+			"Error waiting for nhncloud_blockstorage_backup_v3 %s to be restored to volume %s: %s", backupID, r.VolumeID, err)
+	}
+
+	return resourceBlockStorageBackupRestoreV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageBackupRestoreV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	v, err := volumes.Get(blockStorageClient, d.Get("volume_id").(string)).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_blockstorage_volume_v3"))
+	}
+
+	d.Set("volume_name", v.Name)
+	d.Set("size", v.Size)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageBackupRestoreV3Delete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing nhncloud_blockstorage_backup_restore_v3 %s from the state, the volume is left as it is", d.Id())
+
+	return nil
+}
@@ -0,0 +1,249 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
+)
+
+func resourceBlockStorageBackupV3() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBlockStorageBackupV3Create,
+		ReadContext:   resourceBlockStorageBackupV3Read,
+		UpdateContext: resourceBlockStorageBackupV3Update,
+		DeleteContext: resourceBlockStorageBackupV3Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"container": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"incremental": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"has_dependent_backups": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockStorageBackupV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+	blockStorageClient.Microversion = blockStorageBackupV3Microversion
+
+	createOpts := backups.CreateOpts{
+		VolumeID:    d.Get("volume_id").(string),
+		SnapshotID:  d.Get("snapshot_id").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Container:   d.Get("container").(string),
+		Incremental: d.Get("incremental").(bool),
+		Force:       d.Get("force").(bool),
+		Metadata:    expandToMapStringString(d.Get("metadata").(map[string]interface{})),
+	}
+
+	log.Printf("[DEBUG] nhncloud_blockstorage_backup_v3 create options: %#v", createOpts)
+	b, err := backups.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_blockstorage_backup_v3: %s", err)
+	}
+
+	d.SetId(b.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "backing-up"},
+		Target:     []string{"available"},
+		Refresh:    blockStorageBackupV3StateRefreshFunc(blockStorageClient, b.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf(
+			"Error waiting for nhncloud_blockstorage_backup_v3 %s to become ready: %s", b.ID, err)
+	}
+
+	return resourceBlockStorageBackupV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageBackupV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+	blockStorageClient.Microversion = blockStorageBackupV3Microversion
+
+	b, err := backups.Get(blockStorageClient, d.Id()).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_blockstorage_backup_v3"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_blockstorage_backup_v3 %s: %#v", d.Id(), b)
+
+	d.Set("volume_id", b.VolumeID)
+	d.Set("snapshot_id", b.SnapshotID)
+	d.Set("name", b.Name)
+	d.Set("description", b.Description)
+	d.Set("container", b.Container)
+	d.Set("incremental", b.IsIncremental)
+	d.Set("metadata", flattenBlockStorageBackupV3Metadata(b.Metadata))
+	d.Set("size", b.Size)
+	d.Set("status", b.Status)
+	d.Set("has_dependent_backups", b.HasDependentBackups)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageBackupV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+	blockStorageClient.Microversion = blockStorageBackupV3Microversion
+
+	name := d.Get("name").(string)
+	description := d.Get("description").(string)
+	updateOpts := backups.UpdateOpts{
+		Name:        &name,
+		Description: &description,
+	}
+
+	if d.HasChange("metadata") {
+		updateOpts.Metadata = expandToMapStringString(d.Get("metadata").(map[string]interface{}))
+	}
+
+	log.Printf("[DEBUG] nhncloud_blockstorage_backup_v3 %s update options: %#v", d.Id(), updateOpts)
+	if _, err := backups.Update(blockStorageClient, d.Id(), updateOpts).Extract(); err != nil {
+		return diag.Errorf("Error updating nhncloud_blockstorage_backup_v3 %s: %s", d.Id(), err)
+	}
+
+	return resourceBlockStorageBackupV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageBackupV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	if err := backups.Delete(blockStorageClient, d.Id()).ExtractErr(); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_blockstorage_backup_v3"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting", "available"},
+		Target:     []string{"deleted"},
+		Refresh:    blockStorageBackupV3StateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_blockstorage_backup_v3 %s to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitBlockStorageV3Backup_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_blockstorage_backup_v3", "backups"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_blockstorage_volume_v3", "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitBlockStorageV3BackupConfig("backup_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_blockstorage_backup_v3.backup_1", "volume_id",
+						"nhncloud_blockstorage_volume_v3.volume_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "status", "available"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "size", "10"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "container", "volumebackups"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "incremental", "false"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "metadata.policy", "daily"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_2", "container", "weekly"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_2", "incremental", "true"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_blockstorage_backup_v3.backup_2", "backups", "is_incremental", "true"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_blockstorage_backup_v3.backup_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// force only applies to the create request, and the
+				// incremental backup was taken after backup_1 was read.
+				ImportStateVerifyIgnore: []string{"force", "has_dependent_backups"},
+			},
+			{
+				Config: providerConfig + testUnitBlockStorageV3BackupConfig("backup_renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "name", "backup_renamed"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_v3.backup_1", "has_dependent_backups", "true"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_blockstorage_backup_v3.backup_1", "backups", "name", "backup_renamed"),
+				),
+			},
+			{
+				Config: providerConfig + testUnitBlockStorageV3BackupConfig("backup_renamed") + `
+resource "nhncloud_blockstorage_backup_restore_v3" "restore_1" {
+  backup_id = nhncloud_blockstorage_backup_v3.backup_1.id
+  name      = "restored"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_restore_v3.restore_1", "volume_name", "restored"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_backup_restore_v3.restore_1", "size", "10"),
+					resource.TestCheckResourceAttrSet("nhncloud_blockstorage_backup_restore_v3.restore_1", "volume_id"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3Backup_incrementalWithoutFull(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+}
+
+resource "nhncloud_blockstorage_backup_v3" "backup_1" {
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_1.id
+  incremental = true
+}
+`,
+				ExpectError: regexp.MustCompile("No backups available"),
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3RetentionPolicy_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	old := testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", 240*time.Hour)
+	older := testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", 480*time.Hour)
+	testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", time.Hour)
+	testUnitBlockStorageV3PutBackup(server, "volume-1", "weekly", 480*time.Hour)
+	testUnitBlockStorageV3PutBackup(server, "volume-2", "daily", 480*time.Hour)
+	oldSnapshot := server.Put("snapshots", map[string]interface{}{
+		"volume_id":  "volume-1",
+		"status":     "available",
+		"size":       10,
+		"metadata":   map[string]interface{}{"policy": "daily"},
+		"created_at": time.Now().Add(-240 * time.Hour).UTC().Format(testUnitCinderTimeFormat),
+	})
+
+	var newer string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitBlockStorageV3RetentionPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.#", "2"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.0", older),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.1", old),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_snapshot_ids.#", "1"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_snapshot_ids.0", oldSnapshot),
+					testUnitCheckMockCloudCount(server, "backups", 3),
+				),
+				// The policy prunes again on every apply.
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					newer = testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", 72*time.Hour)
+				},
+				Config: providerConfig + testUnitBlockStorageV3RetentionPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.#", "1"),
+					func(s *terraform.State) error {
+						return resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.0", newer)(s)
+					},
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_snapshot_ids.#", "0"),
+					testUnitCheckMockCloudCount(server, "backups", 3),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3RetentionPolicy_dependentBackupCountsTowardKeep(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	parent := testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", 240*time.Hour)
+	older := testUnitBlockStorageV3PutBackup(server, "volume-1", "daily", 480*time.Hour)
+	child := testUnitBlockStorageV3PutBackup(server, "volume-1", "weekly", time.Hour)
+	server.Update("backups", child, map[string]interface{}{"parent_id": parent})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitBlockStorageV3RetentionPolicyConfig,
+				Check: resource.ComposeTestCheckFunc(
+					// The parent is the one backup kept, so the older one is pruned.
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.#", "1"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_retention_policy_v3.policy_1", "pruned_backup_ids.0", older),
+					testUnitCheckMockCloudCount(server, "backups", 2),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3RetentionPolicy_emptyMatch(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_blockstorage_retention_policy_v3" "policy_1" {
+  match_metadata = {}
+  keep           = 1
+}
+`,
+				ExpectError: regexp.MustCompile("match_metadata must not be empty"),
+			},
+		},
+	})
+}
+
+// testUnitCinderTimeFormat is the timestamp format of the block storage API.
+const testUnitCinderTimeFormat = "2006-01-02T15:04:05.000000"
+
+func testUnitBlockStorageV3PutBackup(server *mockcloud.Server, volumeID, policy string, age time.Duration) string {
+	return server.Put("backups", map[string]interface{}{
+		"volume_id":  volumeID,
+		"status":     "available",
+		"size":       10,
+		"container":  "volumebackups",
+		"metadata":   map[string]interface{}{"policy": policy},
+		"created_at": time.Now().Add(-age).UTC().Format(testUnitCinderTimeFormat),
+	})
+}
+
+func testUnitCheckMockCloudCount(server *mockcloud.Server, kind string, count int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		if got := len(server.List(kind)); got != count {
+			return fmt.Errorf("Expected %d %s, got %d", count, kind, got)
+		}
+
+		return nil
+	}
+}
+
+const testUnitBlockStorageV3RetentionPolicyConfig = `
+resource "nhncloud_blockstorage_retention_policy_v3" "policy_1" {
+  volume_id = "volume-1"
+  keep      = 1
+  max_age   = "48h"
+
+  match_metadata = {
+    policy = "daily"
+  }
+}
+`
+
+func testUnitBlockStorageV3BackupConfig(name string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+}
+
+resource "nhncloud_blockstorage_backup_v3" "backup_1" {
+  name        = "%s"
+  description = "nightly"
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_1.id
+  force       = true
+
+  metadata = {
+    policy = "daily"
+  }
+}
+
+resource "nhncloud_blockstorage_backup_v3" "backup_2" {
+  name        = "backup_2"
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_1.id
+  container   = "weekly"
+  incremental = true
+
+  depends_on = [nhncloud_blockstorage_backup_v3.backup_1]
+}
+`, name)
+}
@@ -0,0 +1,334 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/utils/terraform/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/backups"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+)
+
+// resourceBlockStorageRetentionPolicyV3 prunes the backups and snapshots
+// matching its metadata on every apply. Nothing is created in the cloud, so
+// deleting it only removes it from the state.
+func resourceBlockStorageRetentionPolicyV3() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBlockStorageRetentionPolicyV3Create,
+		ReadContext:   resourceBlockStorageRetentionPolicyV3Read,
+		UpdateContext: resourceBlockStorageRetentionPolicyV3Update,
+		DeleteContext: resourceBlockStorageRetentionPolicyV3Delete,
+
+		CustomizeDiff: resourceBlockStorageRetentionPolicyV3CustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// MapKeyLenBetween only checks the keys, an empty map is rejected
+			// by the CustomizeDiff.
+			"match_metadata": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validation.MapKeyLenBetween(1, 255),
+			},
+
+			"keep": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				AtLeastOneOf: []string{"keep", "max_age"},
+			},
+
+			"max_age": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateBlockStorageRetentionV3MaxAge,
+				AtLeastOneOf: []string{"keep", "max_age"},
+			},
+
+			"prune_backups": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"prune_snapshots": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"pruned_backup_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"pruned_snapshot_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func validateBlockStorageRetentionV3MaxAge(v interface{}, k string) ([]string, []error) {
+	maxAge, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration such as \"720h\": %s", k, err)}
+	}
+	if maxAge <= 0 {
+		return nil, []error{fmt.Errorf("%q must be positive, got %s", k, maxAge)}
+	}
+
+	return nil, nil
+}
+
+// resourceBlockStorageRetentionPolicyV3CustomizeDiff plans a new pruning on
+// every apply once the policy exists.
+func resourceBlockStorageRetentionPolicyV3CustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	// An empty match would select every backup and snapshot.
+	if diff.NewValueKnown("match_metadata") && len(diff.Get("match_metadata").(map[string]interface{})) == 0 {
+		return fmt.Errorf("match_metadata must not be empty, it would select every backup and snapshot")
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if err := diff.SetNewComputed("pruned_backup_ids"); err != nil {
+		return err
+	}
+
+	return diff.SetNewComputed("pruned_snapshot_ids")
+}
+
+func resourceBlockStorageRetentionPolicyV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := blockStorageRetentionPolicyV3Prune(d, config); err != nil {
+		return diag.FromErr(err)
+	}
+
+	match := expandToMapStringString(d.Get("match_metadata").(map[string]interface{}))
+	parts := []string{GetRegion(d, config), d.Get("volume_id").(string)}
+	for k, v := range match {
+		parts = append(parts, k+"="+v)
+	}
+	sort.Strings(parts[2:])
+	d.SetId(hashcode.Strings(parts))
+
+	return resourceBlockStorageRetentionPolicyV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageRetentionPolicyV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageRetentionPolicyV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := blockStorageRetentionPolicyV3Prune(d, config); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceBlockStorageRetentionPolicyV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageRetentionPolicyV3Delete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing nhncloud_blockstorage_retention_policy_v3 %s from the state, no backups or snapshots are deleted", d.Id())
+
+	return nil
+}
+
+// blockStorageRetentionPolicyV3Prune deletes the expired backups and
+// snapshots of the policy and records their IDs.
+func blockStorageRetentionPolicyV3Prune(d *schema.ResourceData, config *Config) error {
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+	blockStorageClient.Microversion = blockStorageBackupV3Microversion
+
+	match := expandToMapStringString(d.Get("match_metadata").(map[string]interface{}))
+	if len(match) == 0 {
+		return fmt.Errorf("match_metadata must not be empty, it would select every backup and snapshot")
+	}
+
+	var maxAge time.Duration
+	if v := d.Get("max_age").(string); v != "" {
+		maxAge, err = time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("Error parsing max_age: %s", err)
+		}
+	}
+
+	keep := d.Get("keep").(int)
+	volumeID := d.Get("volume_id").(string)
+	now := time.Now().UTC()
+
+	prunedBackups := []string{}
+	if d.Get("prune_backups").(bool) {
+		prunedBackups, err = blockStorageRetentionPolicyV3PruneBackups(blockStorageClient, volumeID, match, keep, maxAge, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	prunedSnapshots := []string{}
+	if d.Get("prune_snapshots").(bool) {
+		prunedSnapshots, err = blockStorageRetentionPolicyV3PruneSnapshots(blockStorageClient, volumeID, match, keep, maxAge, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	d.Set("pruned_backup_ids", prunedBackups)
+	d.Set("pruned_snapshot_ids", prunedSnapshots)
+
+	return nil
+}
+
+func blockStorageRetentionPolicyV3PruneBackups(client *gophercloud.ServiceClient, volumeID string, match map[string]string, keep int, maxAge time.Duration, now time.Time) ([]string, error) {
+	allPages, err := backups.ListDetail(client, backups.ListDetailOpts{}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing nhncloud_blockstorage_backup_v3: %s", err)
+	}
+
+	allBackups, err := backups.ExtractBackups(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting nhncloud_blockstorage_backup_v3: %s", err)
+	}
+
+	var items []blockStorageRetentionV3Item
+	hasDependents := make(map[string]bool)
+	for _, b := range allBackups {
+		if b.Status != "available" || (volumeID != "" && b.VolumeID != volumeID) {
+			continue
+		}
+
+		metadata := flattenBlockStorageBackupV3Metadata(b.Metadata)
+		if !blockStorageRetentionV3Matches(metadata, match) {
+			continue
+		}
+
+		items = append(items, blockStorageRetentionV3Item{
+			ID:        b.ID,
+			CreatedAt: b.CreatedAt,
+			Metadata:  metadata,
+		})
+		hasDependents[b.ID] = b.HasDependentBackups
+	}
+
+	pruned := []string{}
+	for _, id := range blockStorageRetentionV3Expired(items, keep, maxAge, now) {
+		// The incremental backups on top of a backup have to go first, it is
+		// pruned by a later apply.
+		if hasDependents[id] {
+			log.Printf("[DEBUG] Not pruning nhncloud_blockstorage_backup_v3 %s, it has dependent backups", id)
+			continue
+		}
+
+		log.Printf("[DEBUG] Pruning nhncloud_blockstorage_backup_v3 %s", id)
+		if err := backups.Delete(client, id).ExtractErr(); err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				continue
+			}
+			return pruned, fmt.Errorf("Error pruning nhncloud_blockstorage_backup_v3 %s: %s", id, err)
+		}
+		pruned = append(pruned, id)
+	}
+
+	if len(pruned) > 0 {
+		log.Printf("[DEBUG] Pruned nhncloud_blockstorage_backup_v3: %s", strings.Join(pruned, ", "))
+	}
+
+	return pruned, nil
+}
+
+func blockStorageRetentionPolicyV3PruneSnapshots(client *gophercloud.ServiceClient, volumeID string, match map[string]string, keep int, maxAge time.Duration, now time.Time) ([]string, error) {
+	allPages, err := snapshots.List(client, snapshots.ListOpts{VolumeID: volumeID}).AllPages()
+	if err != nil {
+		return nil, fmt.Errorf("Error listing nhncloud_blockstorage_snapshot_v3: %s", err)
+	}
+
+	allSnapshots, err := snapshots.ExtractSnapshots(allPages)
+	if err != nil {
+		return nil, fmt.Errorf("Error extracting nhncloud_blockstorage_snapshot_v3: %s", err)
+	}
+
+	var items []blockStorageRetentionV3Item
+	for _, s := range allSnapshots {
+		if s.Status != "available" || (volumeID != "" && s.VolumeID != volumeID) {
+			continue
+		}
+
+		if !blockStorageRetentionV3Matches(s.Metadata, match) {
+			continue
+		}
+
+		items = append(items, blockStorageRetentionV3Item{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			Metadata:  s.Metadata,
+		})
+	}
+
+	pruned := []string{}
+	for _, id := range blockStorageRetentionV3Expired(items, keep, maxAge, now) {
+		log.Printf("[DEBUG] Pruning nhncloud_blockstorage_snapshot_v3 %s", id)
+		if err := snapshots.Delete(client, id).ExtractErr(); err != nil {
+			if _, ok := err.(gophercloud.ErrDefault404); ok {
+				continue
+			}
+			return pruned, fmt.Errorf("Error pruning nhncloud_blockstorage_snapshot_v3 %s: %s", id, err)
+		}
+		pruned = append(pruned, id)
+	}
+
+	if len(pruned) > 0 {
+		log.Printf("[DEBUG] Pruned nhncloud_blockstorage_snapshot_v3: %s", strings.Join(pruned, ", "))
+	}
+
+	return pruned, nil
+}
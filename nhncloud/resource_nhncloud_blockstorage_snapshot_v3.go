@@ -0,0 +1,238 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+)
+
+func resourceBlockStorageSnapshotV3() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBlockStorageSnapshotV3Create,
+		ReadContext:   resourceBlockStorageSnapshotV3Read,
+		UpdateContext: resourceBlockStorageSnapshotV3Update,
+		DeleteContext: resourceBlockStorageSnapshotV3Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"force": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockStorageSnapshotV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	createOpts := snapshots.CreateOpts{
+		VolumeID:    d.Get("volume_id").(string),
+		Force:       d.Get("force").(bool),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Metadata:    expandToMapStringString(d.Get("metadata").(map[string]interface{})),
+	}
+
+	log.Printf("[DEBUG] nhncloud_blockstorage_snapshot_v3 create options: %#v", createOpts)
+	s, err := snapshots.Create(blockStorageClient, createOpts).Extract()
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_blockstorage_snapshot_v3: %s", err)
+	}
+
+	d.SetId(s.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    blockStorageSnapshotV3StateRefreshFunc(blockStorageClient, s.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf(
+			"Error waiting for nhncloud_blockstorage_snapshot_v3 %s to become ready: %s", s.ID, err)
+	}
+
+	return resourceBlockStorageSnapshotV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageSnapshotV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	s, err := snapshots.Get(blockStorageClient, d.Id()).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_blockstorage_snapshot_v3"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_blockstorage_snapshot_v3 %s: %#v", d.Id(), s)
+
+	d.Set("volume_id", s.VolumeID)
+	d.Set("name", s.Name)
+	d.Set("description", s.Description)
+	d.Set("metadata", s.Metadata)
+	d.Set("size", s.Size)
+	d.Set("status", s.Status)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageSnapshotV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	if d.HasChanges("name", "description") {
+		name := d.Get("name").(string)
+		description := d.Get("description").(string)
+		updateOpts := snapshots.UpdateOpts{
+			Name:        &name,
+			Description: &description,
+		}
+
+		log.Printf("[DEBUG] nhncloud_blockstorage_snapshot_v3 %s update options: %#v", d.Id(), updateOpts)
+		if _, err := snapshots.Update(blockStorageClient, d.Id(), updateOpts).Extract(); err != nil {
+			return diag.Errorf("Error updating nhncloud_blockstorage_snapshot_v3 %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("metadata") {
+		o, n := d.GetChange("metadata")
+		oldMetadata := o.(map[string]interface{})
+		newMetadata := n.(map[string]interface{})
+
+		// Keys that were removed from the configuration are deleted, the
+		// others are created or updated.
+		for key := range oldMetadata {
+			if _, ok := newMetadata[key]; ok {
+				continue
+			}
+
+			log.Printf("[DEBUG] Deleting nhncloud_blockstorage_snapshot_v3 %s metadata %s", d.Id(), key)
+			if err := blockStorageSnapshotV3DeleteMetadatum(blockStorageClient, d.Id(), key); err != nil {
+				return diag.Errorf("Error deleting nhncloud_blockstorage_snapshot_v3 %s metadata %s: %s", d.Id(), key, err)
+			}
+		}
+
+		if len(newMetadata) > 0 {
+			log.Printf("[DEBUG] nhncloud_blockstorage_snapshot_v3 %s metadata: %#v", d.Id(), newMetadata)
+			if err := blockStorageSnapshotV3SetMetadata(blockStorageClient, d.Id(), expandToMapStringString(newMetadata)); err != nil {
+				return diag.Errorf("Error updating metadata of nhncloud_blockstorage_snapshot_v3 %s: %s", d.Id(), err)
+			}
+		}
+	}
+
+	return resourceBlockStorageSnapshotV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageSnapshotV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	if err := snapshots.Delete(blockStorageClient, d.Id()).ExtractErr(); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_blockstorage_snapshot_v3"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting", "available"},
+		Target:     []string{"deleted"},
+		Refresh:    blockStorageSnapshotV3StateRefreshFunc(blockStorageClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_blockstorage_snapshot_v3 %s to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
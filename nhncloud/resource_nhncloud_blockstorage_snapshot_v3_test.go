@@ -0,0 +1,165 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitBlockStorageV3Snapshot_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_blockstorage_snapshot_v3", "snapshots"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_blockstorage_volume_v3", "volumes"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitBlockStorageV3SnapshotConfig("snapshot_1", "pre-upgrade"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_blockstorage_snapshot_v3.snapshot_1", "volume_id",
+						"nhncloud_blockstorage_volume_v3.volume_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "status", "available"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "size", "10"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "metadata.purpose", "pre-upgrade"),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_blockstorage_snapshot_v3.snapshot_1", "id",
+						"nhncloud_blockstorage_snapshot_v3.snapshot_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_blockstorage_snapshot_v3.snapshot_1", "encrypted", "true"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_blockstorage_snapshot_v3.snapshot_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// force only applies to the create request.
+				ImportStateVerifyIgnore: []string{"force"},
+			},
+			{
+				Config: providerConfig + testUnitBlockStorageV3SnapshotConfig("snapshot_renamed", "rollback"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "name", "snapshot_renamed"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "metadata.purpose", "rollback"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_blockstorage_snapshot_v3.snapshot_1", "snapshots", "name", "snapshot_renamed"),
+				),
+			},
+			{
+				// Removing the metadata deletes its keys.
+				Config: providerConfig + testUnitBlockStorageV3SnapshotConfig("snapshot_renamed", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_snapshot_v3.snapshot_1", "metadata.%", "0"),
+					testUnitCheckBlockStorageV3SnapshotMetadataCount(server, "nhncloud_blockstorage_snapshot_v3.snapshot_1", 0),
+				),
+			},
+			{
+				Config: providerConfig + testUnitBlockStorageV3SnapshotConfig("snapshot_renamed", "rollback") + `
+resource "nhncloud_blockstorage_volume_revert_v3" "revert_1" {
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_1.id
+  snapshot_id = nhncloud_blockstorage_snapshot_v3.snapshot_1.id
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_volume_revert_v3.revert_1", "size", "10"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_blockstorage_volume_v3.volume_1", "volumes", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3VolumeRevert_otherVolume(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+}
+
+resource "nhncloud_blockstorage_volume_v3" "volume_2" {
+  name = "volume_2"
+  size = 10
+}
+
+resource "nhncloud_blockstorage_snapshot_v3" "snapshot_1" {
+  volume_id = nhncloud_blockstorage_volume_v3.volume_1.id
+}
+
+resource "nhncloud_blockstorage_volume_revert_v3" "revert_1" {
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_2.id
+  snapshot_id = nhncloud_blockstorage_snapshot_v3.snapshot_1.id
+}
+`,
+				ExpectError: regexp.MustCompile("was taken from volume"),
+			},
+		},
+	})
+}
+
+func testUnitCheckBlockStorageV3SnapshotMetadataCount(server *mockcloud.Server, n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		snapshot, ok := server.Get("snapshots", rs.Primary.ID)
+		if !ok {
+			return fmt.Errorf("snapshot %s not found", rs.Primary.ID)
+		}
+
+		if metadata, _ := snapshot["metadata"].(map[string]interface{}); len(metadata) != count {
+			return fmt.Errorf("Expected %d metadata keys on snapshot %s, got %v", count, rs.Primary.ID, metadata)
+		}
+
+		return nil
+	}
+}
+
+// testUnitBlockStorageV3SnapshotConfig leaves the snapshot metadata out
+// when purpose is empty.
+func testUnitBlockStorageV3SnapshotConfig(name, purpose string) string {
+	metadata := ""
+	if purpose != "" {
+		metadata = fmt.Sprintf(`
+  metadata = {
+    purpose = "%s"
+  }
+`, purpose)
+	}
+
+	return fmt.Sprintf(`
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+
+  nhn_encryption {
+    skm_appkey = "appkey"
+    skm_key_id = "key-1"
+  }
+}
+
+resource "nhncloud_blockstorage_snapshot_v3" "snapshot_1" {
+  name        = "%s"
+  description = "before the upgrade"
+  volume_id   = nhncloud_blockstorage_volume_v3.volume_1.id
+  force       = true
+%s}
+
+data "nhncloud_blockstorage_snapshot_v3" "snapshot_1" {
+  volume_id = nhncloud_blockstorage_snapshot_v3.snapshot_1.volume_id
+}
+`, name, metadata)
+}
@@ -0,0 +1,135 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+)
+
+// resourceBlockStorageVolumeRevertV3 reverts a volume in place to one of its
+// snapshots when it is created. The revert cannot be undone, so deleting the
+// resource only removes it from the state.
+func resourceBlockStorageVolumeRevertV3() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBlockStorageVolumeRevertV3Create,
+		ReadContext:   resourceBlockStorageVolumeRevertV3Read,
+		DeleteContext: resourceBlockStorageVolumeRevertV3Delete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"snapshot_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBlockStorageVolumeRevertV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	volumeID := d.Get("volume_id").(string)
+	snapshotID := d.Get("snapshot_id").(string)
+
+	s, err := snapshots.Get(blockStorageClient, snapshotID).Extract()
+	if err != nil {
+		return diag.Errorf("Error retrieving nhncloud_blockstorage_snapshot_v3 %s: %s", snapshotID, err)
+	}
+	if s.VolumeID != volumeID {
+		return diag.Errorf("Snapshot %s was taken from volume %s, not %s", snapshotID, s.VolumeID, volumeID)
+	}
+
+	log.Printf("[DEBUG] Reverting nhncloud_blockstorage_volume_v3 %s to snapshot %s", volumeID, snapshotID)
+	if err := blockStorageVolumeV3Revert(blockStorageClient, volumeID, snapshotID); err != nil {
+		return diag.Errorf("Error reverting nhncloud_blockstorage_volume_v3 %s to snapshot %s: %s", volumeID, snapshotID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", volumeID, snapshotID))
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"reverting"},
+		Target:     []string{"available"},
+		Refresh:    blockStorageVolumeV3StateRefreshFunc(blockStorageClient, volumeID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf(
+			"Error waiting for nhncloud_blockstorage_volume_v3 %s to be reverted: %s", volumeID, err)
+	}
+
+	return resourceBlockStorageVolumeRevertV3Read(ctx, d, meta)
+}
+
+func resourceBlockStorageVolumeRevertV3Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	blockStorageClient, err := config.BlockStorageV3Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud block storage client: %s", err)
+	}
+
+	v, err := volumes.Get(blockStorageClient, d.Get("volume_id").(string)).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_blockstorage_volume_v3"))
+	}
+
+	d.Set("size", v.Size)
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceBlockStorageVolumeRevertV3Delete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing nhncloud_blockstorage_volume_revert_v3 %s from the state, the volume is left as it is", d.Id())
+
+	return nil
+}
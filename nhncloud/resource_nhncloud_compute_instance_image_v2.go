@@ -0,0 +1,254 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+)
+
+// resourceComputeInstanceImageV2 manages an image created from an instance.
+// The image outlives the instance, so only the image is deleted with the
+// resource.
+func resourceComputeInstanceImageV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceComputeInstanceImageV2Create,
+		ReadContext:   resourceComputeInstanceImageV2Read,
+		DeleteContext: resourceComputeInstanceImageV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"instance_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"metadata": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"stop_before_create": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"min_disk_gb": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"size_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceComputeInstanceImageV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
+	}
+	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud image client: %s", err)
+	}
+
+	instanceID := d.Get("instance_id").(string)
+
+	server, err := servers.Get(computeClient, instanceID).Extract()
+	if err != nil {
+		return diag.Errorf("Error retrieving nhncloud_compute_instance_v2 %s: %s", instanceID, err)
+	}
+
+	if d.Get("stop_before_create").(bool) && server.Status == "ACTIVE" {
+		log.Printf("[DEBUG] Stopping nhncloud_compute_instance_v2 %s before creating an image", instanceID)
+		if err := computeInstanceImageV2SetPowerState(ctx, computeClient, d, instanceID, "SHUTOFF"); err != nil {
+			return diag.FromErr(err)
+		}
+
+		// Start the instance again whether or not the image was created.
+		defer func() {
+			log.Printf("[DEBUG] Starting nhncloud_compute_instance_v2 %s again after creating an image", instanceID)
+			if err := computeInstanceImageV2SetPowerState(ctx, computeClient, d, instanceID, "ACTIVE"); err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+		}()
+	}
+
+	createOpts := servers.CreateImageOpts{
+		Name:     d.Get("name").(string),
+		Metadata: expandToMapStringString(d.Get("metadata").(map[string]interface{})),
+	}
+
+	log.Printf("[DEBUG] nhncloud_compute_instance_image_v2 create options: %#v", createOpts)
+	imageID, err := servers.CreateImage(computeClient, instanceID, createOpts).ExtractImageID()
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_compute_instance_image_v2 from instance %s: %s", instanceID, err)
+	}
+
+	d.SetId(imageID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{string(images.ImageStatusQueued), string(images.ImageStatusSaving)},
+		Target:     []string{string(images.ImageStatusActive)},
+		Refresh:    resourceImagesImageV2RefreshFunc(imageClient, imageID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_compute_instance_image_v2 %s to become active: %s", imageID, err)
+	}
+
+	return resourceComputeInstanceImageV2Read(ctx, d, meta)
+}
+
+func resourceComputeInstanceImageV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud image client: %s", err)
+	}
+
+	img, err := images.Get(imageClient, d.Id()).Extract()
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_compute_instance_image_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_compute_instance_image_v2 %s: %#v", d.Id(), img)
+
+	if instanceID, ok := img.Properties["instance_uuid"].(string); ok {
+		d.Set("instance_id", instanceID)
+	}
+
+	// The image carries many properties of its own, only the configured
+	// metadata is read back.
+	metadata := make(map[string]string)
+	for k := range d.Get("metadata").(map[string]interface{}) {
+		if v, ok := img.Properties[k]; ok {
+			metadata[k] = fmt.Sprint(v)
+		}
+	}
+
+	d.Set("name", img.Name)
+	d.Set("metadata", metadata)
+	d.Set("image_id", img.ID)
+	d.Set("status", img.Status)
+	d.Set("min_disk_gb", img.MinDiskGigabytes)
+	d.Set("size_bytes", img.SizeBytes)
+	d.Set("created_at", img.CreatedAt.Format(time.RFC3339))
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceComputeInstanceImageV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	imageClient, err := config.ImageV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud image client: %s", err)
+	}
+
+	if err := images.Delete(imageClient, d.Id()).ExtractErr(); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_compute_instance_image_v2"))
+	}
+
+	return nil
+}
+
+// computeInstanceImageV2SetPowerState stops or starts the instance and waits
+// for it to reach status.
+func computeInstanceImageV2SetPowerState(ctx context.Context, computeClient *gophercloud.ServiceClient, d *schema.ResourceData, instanceID, status string) error {
+	var err error
+	if status == "SHUTOFF" {
+		err = startstop.Stop(computeClient, instanceID).ExtractErr()
+	} else {
+		err = startstop.Start(computeClient, instanceID).ExtractErr()
+	}
+	if err != nil {
+		return fmt.Errorf("Error changing the power state of nhncloud_compute_instance_v2 %s: %s", instanceID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Target:     []string{status},
+		Refresh:    ServerV2StateRefreshFunc(computeClient, instanceID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for nhncloud_compute_instance_v2 %s to become %s: %s", instanceID, status, err)
+	}
+
+	return nil
+}
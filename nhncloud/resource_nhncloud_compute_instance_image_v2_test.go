@@ -0,0 +1,137 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitComputeV2InstanceImage_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_image_v2", "images"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceImageConfig(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_compute_instance_image_v2.image_1", "instance_id",
+						"nhncloud_compute_instance_v2.instance_1", "id"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_compute_instance_image_v2.image_1", "image_id",
+						"nhncloud_compute_instance_image_v2.image_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_image_v2.image_1", "name", "golden-1"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_image_v2.image_1", "status", "active"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_image_v2.image_1", "metadata.pipeline", "golden"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_image_v2.image_1", "images", "pipeline", "golden"),
+					// The instance is started again once the image is taken.
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_compute_instance_image_v2.image_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only the configured metadata is read back, and
+				// stop_before_create only applies to the create request.
+				ImportStateVerifyIgnore: []string{"metadata", "stop_before_create"},
+			},
+		},
+	})
+}
+
+func TestUnitComputeV2InstanceImage_startAfterError(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	instanceConfig := providerConfig + testUnitComputeV2InstanceImageConfigInstance(imageID, flavorID, networkID)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: instanceConfig,
+			},
+			{
+				PreConfig: func() {
+					for _, s := range server.List("servers") {
+						server.Update("servers", s["id"].(string), map[string]interface{}{
+							"_create_image_error": "Cannot create an image while the instance is being snapshotted",
+						})
+					}
+				},
+				Config:      providerConfig + testUnitComputeV2InstanceImageConfig(imageID, flavorID, networkID),
+				ExpectError: regexp.MustCompile("being snapshotted"),
+			},
+			{
+				// The stopped instance was started again after the error.
+				Config: instanceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitComputeV2InstanceImageConfigInstance(imageID, flavorID, networkID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, imageID, flavorID, networkID)
+}
+
+func testUnitComputeV2InstanceImageConfig(imageID, flavorID, networkID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+
+resource "nhncloud_compute_instance_image_v2" "image_1" {
+  instance_id        = nhncloud_compute_instance_v2.instance_1.id
+  name               = "golden-1"
+  stop_before_create = true
+
+  metadata = {
+    pipeline = "golden"
+  }
+}
+`, imageID, flavorID, networkID)
+}
@@ -27,6 +27,12 @@ func resourceComputeKeypairV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -69,7 +75,10 @@ func resourceComputeKeypairV2() *schema.Resource {
 }
 
 func resourceComputeKeypairV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
@@ -107,7 +116,10 @@ func resourceComputeKeypairV2Create(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceComputeKeypairV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
@@ -143,7 +155,10 @@ func resourceComputeKeypairV2Read(_ context.Context, d *schema.ResourceData, met
 }
 
 func resourceComputeKeypairV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud compute client: %s", err)
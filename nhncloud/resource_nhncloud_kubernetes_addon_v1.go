@@ -0,0 +1,263 @@
+package nhncloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceKubernetesAddonV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesAddonV1Create,
+		ReadContext:   resourceKubernetesAddonV1Read,
+		UpdateContext: resourceKubernetesAddonV1Update,
+		DeleteContext: resourceKubernetesAddonV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"options": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceKubernetesAddonV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	clusterID := d.Get("cluster_id").(string)
+	addon := kubernetesAddonV1{
+		Name:    d.Get("name").(string),
+		Version: d.Get("version").(string),
+	}
+	if options := d.Get("options").(map[string]interface{}); len(options) > 0 {
+		addon.Options = options
+	}
+
+	log.Printf("[DEBUG] nhncloud_kubernetes_addon_v1 install options: %#v", addon)
+	if err := kubernetesAddonV1Install(kubernetesClient, clusterID, addon); err != nil {
+		return diag.Errorf("Error installing nhncloud_kubernetes_addon_v1 %s in cluster %s: %s", addon.Name, clusterID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", clusterID, addon.Name))
+
+	if err := kubernetesAddonV1WaitForStatus(ctx, kubernetesClient, clusterID, addon.Name, schema.TimeoutCreate, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Installed nhncloud_kubernetes_addon_v1 %s", d.Id())
+
+	return resourceKubernetesAddonV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesAddonV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	clusterID, name, err := parseKubernetesAddonID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	addon, err := kubernetesAddonV1Get(kubernetesClient, clusterID, name)
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_kubernetes_addon_v1"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_kubernetes_addon_v1 %s: %#v", d.Id(), addon)
+
+	d.Set("cluster_id", clusterID)
+	d.Set("name", addon.Name)
+	d.Set("version", addon.Version)
+	d.Set("status", addon.Status)
+	d.Set("region", GetRegion(d, config))
+
+	options := flattenKubernetesAddonV1Options(addon.Options, d.Get("options").(map[string]interface{}))
+	if err := d.Set("options", options); err != nil {
+		log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_addon_v1 options: %s", err)
+	}
+
+	return nil
+}
+
+func resourceKubernetesAddonV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	clusterID, name, err := parseKubernetesAddonID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	current, err := kubernetesAddonV1Get(kubernetesClient, clusterID, name)
+	if err != nil {
+		return diag.Errorf("Error retrieving nhncloud_kubernetes_addon_v1 %s: %s", d.Id(), err)
+	}
+
+	oldOptions, newOptions := d.GetChange("options")
+	addon := kubernetesAddonV1{
+		Name:    name,
+		Version: d.Get("version").(string),
+		Options: expandKubernetesAddonV1Options(current.Options,
+			oldOptions.(map[string]interface{}), newOptions.(map[string]interface{})),
+	}
+
+	log.Printf("[DEBUG] Updating nhncloud_kubernetes_addon_v1 %s: %#v", d.Id(), addon)
+	if err := kubernetesAddonV1Update(kubernetesClient, clusterID, addon); err != nil {
+		return diag.Errorf("Error updating nhncloud_kubernetes_addon_v1 %s: %s", d.Id(), err)
+	}
+
+	if err := kubernetesAddonV1WaitForStatus(ctx, kubernetesClient, clusterID, name, schema.TimeoutUpdate, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKubernetesAddonV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesAddonV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	clusterID, name, err := parseKubernetesAddonID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := kubernetesAddonV1Uninstall(kubernetesClient, clusterID, name); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error uninstalling nhncloud_kubernetes_addon_v1"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"INSTALLED", "UNINSTALLING"},
+		Target:     []string{"DELETED"},
+		Refresh:    kubernetesAddonV1StateRefreshFunc(kubernetesClient, clusterID, name),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_kubernetes_addon_v1 %s to be uninstalled: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// flattenKubernetesAddonV1Options returns the configured addon options as
+// strings. Options that the API sets on its own are left out, and values that
+// are not strings are JSON encoded.
+func flattenKubernetesAddonV1Options(options map[string]interface{}, configured map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(configured))
+	for k := range configured {
+		if v, ok := options[k]; ok {
+			result[k] = flattenKubernetesAddonV1Option(v)
+		}
+	}
+
+	return result
+}
+
+func flattenKubernetesAddonV1Option(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(b)
+}
+
+// expandKubernetesAddonV1Options returns the options to update an addon with.
+// The current options of the addon are kept as returned by the API, except
+// for the removed ones and the changed configured ones.
+func expandKubernetesAddonV1Options(current, oldOptions, newOptions map[string]interface{}) map[string]interface{} {
+	options := make(map[string]interface{}, len(current)+len(newOptions))
+	for k, v := range current {
+		_, removed := oldOptions[k]
+		if _, ok := newOptions[k]; removed && !ok {
+			continue
+		}
+		options[k] = v
+	}
+
+	for k, v := range newOptions {
+		if c, ok := current[k]; ok && flattenKubernetesAddonV1Option(c) == v.(string) {
+			continue
+		}
+		options[k] = v
+	}
+
+	return options
+}
+
+func parseKubernetesAddonID(id string) (string, string, error) {
+	idParts := strings.Split(id, "/")
+	if len(idParts) < 2 {
+		return "", "", fmt.Errorf("Unable to determine addon ID %s", id)
+	}
+
+	return idParts[0], idParts[1], nil
+}
@@ -0,0 +1,102 @@
+package nhncloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitKubernetesV1Addon_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	clusterID := testUnitKubernetesV1PutCluster(server)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckKubernetesV1AddonCount(server, clusterID, 0),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitKubernetesV1AddonConfig(clusterID, "v0.6.4-nks1", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_addon_v1.addon_1", "id", clusterID+"/metrics-server"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_addon_v1.addon_1", "status", "INSTALLED"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_addon_v1.addon_1", "options.replicas", "1"),
+					testUnitCheckKubernetesV1AddonCount(server, clusterID, 1),
+				),
+			},
+			{
+				Config: providerConfig + testUnitKubernetesV1AddonConfig(clusterID, "v0.7.1-nks1", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_addon_v1.addon_1", "version", "v0.7.1-nks1"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_addon_v1.addon_1", "options.replicas", "2"),
+					testUnitCheckKubernetesV1AddonCount(server, clusterID, 1),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_kubernetes_addon_v1.addon_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Only the configured options are read.
+				ImportStateVerifyIgnore: []string{"options"},
+			},
+		},
+	})
+}
+
+func TestUnitKubernetesV1AddonOptions(t *testing.T) {
+	current := map[string]interface{}{
+		"replicas":    float64(2),
+		"enabled":     true,
+		"tolerations": map[string]interface{}{"key": "dedicated"},
+		"log_level":   "info",
+	}
+
+	flattened := flattenKubernetesAddonV1Options(current, map[string]interface{}{"replicas": "1", "enabled": "", "missing": ""})
+	assert.Equal(t, map[string]interface{}{"replicas": "2", "enabled": "true"}, flattened)
+
+	// The removed log_level is dropped, the unchanged enabled keeps its type
+	// and the options set by the API are sent back as they are.
+	options := expandKubernetesAddonV1Options(current,
+		map[string]interface{}{"replicas": "2", "enabled": "true", "log_level": "info"},
+		map[string]interface{}{"replicas": "3", "enabled": "true"})
+	assert.Equal(t, map[string]interface{}{
+		"replicas":    "3",
+		"enabled":     true,
+		"tolerations": map[string]interface{}{"key": "dedicated"},
+	}, options)
+}
+
+func testUnitCheckKubernetesV1AddonCount(server *mockcloud.Server, clusterID string, count int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		cluster, ok := server.Get("clusters", clusterID)
+		if !ok {
+			return fmt.Errorf("cluster %s not found in the mock cloud", clusterID)
+		}
+
+		addons, _ := cluster["addons"].([]interface{})
+		if len(addons) != count {
+			return fmt.Errorf("Expected %d addons, got %d", count, len(addons))
+		}
+
+		return nil
+	}
+}
+
+func testUnitKubernetesV1AddonConfig(clusterID, version, replicas string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_kubernetes_addon_v1" "addon_1" {
+  cluster_id = "%s"
+  name       = "metrics-server"
+  version    = "%s"
+
+  options = {
+    replicas = "%s"
+  }
+}
+`, clusterID, version, replicas)
+}
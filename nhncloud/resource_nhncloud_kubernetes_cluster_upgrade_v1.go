@@ -0,0 +1,368 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/clusters"
+	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/nodegroups"
+)
+
+// kubernetesClusterUpgradeV1MasterNodeGroup is the node group of the control
+// plane. It can only be upgraded by name.
+const kubernetesClusterUpgradeV1MasterNodeGroup = "default-master"
+
+// resourceKubernetesClusterUpgradeV1 upgrades the control plane of a cluster
+// and then its worker node groups, one at a time. Like the node group upgrade
+// it is a one-time operation, deleting it only removes it from the state.
+func resourceKubernetesClusterUpgradeV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKubernetesClusterUpgradeV1Create,
+		ReadContext:   resourceKubernetesClusterUpgradeV1Read,
+		UpdateContext: resourceKubernetesClusterUpgradeV1Update,
+		DeleteContext: resourceKubernetesClusterUpgradeV1Delete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Minute),
+			Update: schema.DefaultTimeout(180 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"cluster_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"nodegroup_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"num_buffer_nodes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+
+			"num_max_unavailable_nodes": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"nodegroups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKubernetesClusterUpgradeV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := kubernetesClusterUpgradeV1Run(ctx, d, meta, schema.TimeoutCreate); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(d.Get("cluster_id").(string))
+
+	return resourceKubernetesClusterUpgradeV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesClusterUpgradeV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	kubernetesClient.Microversion = kubernetesV1NodeGroupMinMicroversion
+
+	// A selected node group that was deleted since is left out, so that it
+	// does not fail the refresh.
+	workers, err := kubernetesClusterUpgradeV1Workers(kubernetesClient, d.Id(), d.Get("nodegroup_ids").([]interface{}), true)
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error retrieving nhncloud_kubernetes_cluster_upgrade_v1"))
+	}
+
+	nodeGroups := make([]map[string]interface{}, 0, len(workers))
+	for _, ng := range workers {
+		nodeGroups = append(nodeGroups, map[string]interface{}{
+			"id":      ng.UUID,
+			"name":    ng.Name,
+			"version": ng.Version,
+			"status":  ng.Status,
+		})
+	}
+
+	if err := d.Set("nodegroups", nodeGroups); err != nil {
+		log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_cluster_upgrade_v1 nodegroups: %s", err)
+	}
+	d.Set("cluster_id", d.Id())
+	d.Set("region", GetRegion(d, config))
+
+	return nil
+}
+
+func resourceKubernetesClusterUpgradeV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChanges("version", "nodegroup_ids") {
+		if err := kubernetesClusterUpgradeV1Run(ctx, d, meta, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKubernetesClusterUpgradeV1Read(ctx, d, meta)
+}
+
+func resourceKubernetesClusterUpgradeV1Delete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing nhncloud_kubernetes_cluster_upgrade_v1 %s from the state", d.Id())
+
+	return nil
+}
+
+// kubernetesClusterUpgradeV1Run upgrades the control plane and then each
+// worker node group in order. Node groups already at the target version are
+// skipped, so a failed upgrade can be resumed by applying again.
+func kubernetesClusterUpgradeV1Run(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout string) error {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return fmt.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	kubernetesClient.Microversion = kubernetesV1NodeGroupMinMicroversion
+
+	clusterID := d.Get("cluster_id").(string)
+	upgradeOpts := nodegroups.UpgradeOpts{
+		Version:                d.Get("version").(string),
+		NumBufferNodes:         d.Get("num_buffer_nodes").(int),
+		NumMaxUnavailableNodes: d.Get("num_max_unavailable_nodes").(int),
+	}
+
+	// Node groups can only be upgraded while the cluster is stable.
+	clusterStateConf := &resource.StateChangeConf{
+		Pending:    []string{"UPDATE_IN_PROGRESS", "CREATE_IN_PROGRESS"},
+		Target:     []string{"UPDATE_COMPLETE", "CREATE_COMPLETE"},
+		Refresh:    kubernetesClusterV1StateRefreshFunc(kubernetesClient, clusterID),
+		Timeout:    d.Timeout(timeout),
+		Delay:      30 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for nhncloud_kubernetes_cluster_v1 %s to be stable before upgrading it", clusterID)
+	if _, err := clusterStateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("Error waiting for nhncloud_kubernetes_cluster_v1 %s to be stable before upgrading it: %s", clusterID, err)
+	}
+
+	workers, err := kubernetesClusterUpgradeV1Workers(kubernetesClient, clusterID, d.Get("nodegroup_ids").([]interface{}), false)
+	if err != nil {
+		return fmt.Errorf("Error listing nhncloud_kubernetes_nodegroup_v1 of cluster %s: %s", clusterID, err)
+	}
+
+	cluster, err := clusters.Get(kubernetesClient, clusterID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving nhncloud_kubernetes_cluster_v1 %s: %s", clusterID, err)
+	}
+
+	// The control plane has to be upgraded before any worker, and its node
+	// group can only be watched through the cluster status.
+	var report []string
+	if cluster.COEVersion == upgradeOpts.Version {
+		report = append(report, fmt.Sprintf("%s: ALREADY AT %s", kubernetesClusterUpgradeV1MasterNodeGroup, cluster.COEVersion))
+	} else {
+		log.Printf("[DEBUG] Upgrading the control plane of nhncloud_kubernetes_cluster_v1 %s to %s", clusterID, upgradeOpts.Version)
+		_, err = nodegroups.Upgrade(kubernetesClient, clusterID, kubernetesClusterUpgradeV1MasterNodeGroup, upgradeOpts).Extract()
+		if err == nil {
+			// The cluster can still report UPDATE_COMPLETE right after the
+			// upgrade call, so wait for the new version to be applied too.
+			masterStateConf := &resource.StateChangeConf{
+				Pending: []string{"UPGRADING"},
+				Target:  []string{"UPGRADED"},
+				Refresh: kubernetesClusterUpgradeV1StateRefreshFunc(
+					kubernetesClusterV1StateRefreshFunc(kubernetesClient, clusterID), upgradeOpts.Version),
+				Timeout:    d.Timeout(timeout),
+				Delay:      30 * time.Second,
+				MinTimeout: 10 * time.Second,
+			}
+			_, err = masterStateConf.WaitForStateContext(ctx)
+		}
+		if err != nil {
+			report = append(report, fmt.Sprintf("%s: FAILED: %s", kubernetesClusterUpgradeV1MasterNodeGroup, err))
+			for _, ng := range workers {
+				report = append(report, fmt.Sprintf("%s (%s): NOT STARTED", ng.Name, ng.UUID))
+			}
+			return kubernetesClusterUpgradeV1Error(clusterID, upgradeOpts.Version, report)
+		}
+
+		report = append(report, fmt.Sprintf("%s: UPGRADED", kubernetesClusterUpgradeV1MasterNodeGroup))
+	}
+
+	for i, ng := range workers {
+		if ng.Version == upgradeOpts.Version {
+			report = append(report, fmt.Sprintf("%s (%s): ALREADY AT %s", ng.Name, ng.UUID, ng.Version))
+			continue
+		}
+
+		log.Printf("[DEBUG] Upgrading nhncloud_kubernetes_nodegroup_v1 %s of cluster %s to %s", ng.UUID, clusterID, upgradeOpts.Version)
+		_, err = nodegroups.Upgrade(kubernetesClient, clusterID, ng.UUID, upgradeOpts).Extract()
+		if err == nil {
+			nodeGroupStateConf := &resource.StateChangeConf{
+				Pending: []string{"UPGRADING"},
+				Target:  []string{"UPGRADED"},
+				Refresh: kubernetesClusterUpgradeV1StateRefreshFunc(
+					kubernetesNodeGroupV1StateRefreshFunc(kubernetesClient, clusterID, ng.UUID), upgradeOpts.Version),
+				Timeout:    d.Timeout(timeout),
+				Delay:      30 * time.Second,
+				MinTimeout: 10 * time.Second,
+			}
+			_, err = nodeGroupStateConf.WaitForStateContext(ctx)
+		}
+		if err != nil {
+			report = append(report, fmt.Sprintf("%s (%s): FAILED: %s", ng.Name, ng.UUID, err))
+			for _, rest := range workers[i+1:] {
+				report = append(report, fmt.Sprintf("%s (%s): NOT STARTED", rest.Name, rest.UUID))
+			}
+			return kubernetesClusterUpgradeV1Error(clusterID, upgradeOpts.Version, report)
+		}
+
+		report = append(report, fmt.Sprintf("%s (%s): UPGRADED", ng.Name, ng.UUID))
+	}
+
+	log.Printf("[DEBUG] Upgraded nhncloud_kubernetes_cluster_v1 %s to %s:\n%s", clusterID, upgradeOpts.Version, strings.Join(report, "\n"))
+
+	return nil
+}
+
+// kubernetesClusterUpgradeV1StateRefreshFunc wraps a cluster or node group
+// refresh function and only reports UPGRADED once the object is stable and
+// runs the given version.
+func kubernetesClusterUpgradeV1StateRefreshFunc(refresh resource.StateRefreshFunc, version string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		obj, status, err := refresh()
+		if err != nil {
+			return obj, status, err
+		}
+		if status == "DELETE_COMPLETE" {
+			return obj, status, fmt.Errorf("deleted while upgrading to %s", version)
+		}
+
+		var current string
+		switch v := obj.(type) {
+		case *clusters.Cluster:
+			current = v.COEVersion
+		case *nodegroups.Nodegroup:
+			current = v.Version
+		}
+
+		if current == version && (status == "UPDATE_COMPLETE" || status == "CREATE_COMPLETE") {
+			return obj, "UPGRADED", nil
+		}
+
+		log.Printf("[DEBUG] Waiting for the upgrade to %s, currently at %s with status %s", version, current, status)
+
+		return obj, "UPGRADING", nil
+	}
+}
+
+func kubernetesClusterUpgradeV1Error(clusterID, version string, report []string) error {
+	return fmt.Errorf("Error upgrading nhncloud_kubernetes_cluster_v1 %s to %s:\n  %s",
+		clusterID, version, strings.Join(report, "\n  "))
+}
+
+// kubernetesClusterUpgradeV1Workers returns the worker node groups of the
+// cluster to upgrade, either all of them or the selected ones in the given
+// order. Node groups can be selected by ID, name or nhncloud_kubernetes_nodegroup_v1 ID.
+// A selected node group that does not exist is an error, unless skipMissing
+// is set.
+func kubernetesClusterUpgradeV1Workers(client *gophercloud.ServiceClient, clusterID string, selected []interface{}, skipMissing bool) ([]nodegroups.Nodegroup, error) {
+	allPages, err := nodegroups.List(client, clusterID, nodegroups.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+
+	ngs, err := nodegroups.ExtractNodegroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+
+	var workers []nodegroups.Nodegroup
+	for _, ng := range ngs {
+		if ng.Role == "master" || ng.Name == kubernetesClusterUpgradeV1MasterNodeGroup {
+			continue
+		}
+		workers = append(workers, ng)
+	}
+
+	if len(selected) == 0 {
+		return workers, nil
+	}
+
+	result := make([]nodegroups.Nodegroup, 0, len(selected))
+	for _, v := range selected {
+		id := extractNodeGroupIDForUpgrade(v.(string))
+		found := false
+		for _, ng := range workers {
+			if ng.UUID == id || ng.Name == id {
+				result = append(result, ng)
+				found = true
+				break
+			}
+		}
+		if !found {
+			if skipMissing {
+				log.Printf("[DEBUG] Worker node group %s not found in cluster %s, skipping", id, clusterID)
+				continue
+			}
+			return nil, fmt.Errorf("Worker node group %s not found in cluster %s", id, clusterID)
+		}
+	}
+
+	return result, nil
+}
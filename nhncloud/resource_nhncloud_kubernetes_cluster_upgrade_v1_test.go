@@ -0,0 +1,101 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitKubernetesV1ClusterUpgrade_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	clusterID := testUnitKubernetesV1PutCluster(server)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitKubernetesV1ClusterUpgradeConfig(clusterID, "v1.29.3", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "id", clusterID),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.#", "2"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.0.version", "v1.29.3"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.1.version", "v1.29.3"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "clusters", "coe_version", "v1.29.3"),
+				),
+			},
+			{
+				// Only the selected node group is upgraded.
+				Config: providerConfig + testUnitKubernetesV1ClusterUpgradeConfig(clusterID, "v1.30.1", `nodegroup_ids = ["workers-2"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.#", "1"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.0.name", "workers-2"),
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.0.version", "v1.30.1"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "clusters", "coe_version", "v1.30.1"),
+				),
+			},
+			{
+				// A selected node group deleted since does not fail the refresh.
+				PreConfig: func() {
+					for _, ng := range server.List("nodegroups") {
+						if ng["name"] == "workers-2" {
+							server.Delete("nodegroups", ng["id"].(string))
+						}
+					}
+				},
+				Config: providerConfig + testUnitKubernetesV1ClusterUpgradeConfig(clusterID, "v1.30.1", `nodegroup_ids = ["workers-2"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_kubernetes_cluster_upgrade_v1.upgrade_1", "nodegroups.#", "0"),
+				),
+			},
+			{
+				Config:      providerConfig + testUnitKubernetesV1ClusterUpgradeConfig(clusterID, "v1.31.0", `nodegroup_ids = ["missing"]`),
+				ExpectError: regexp.MustCompile("Worker node group missing not found"),
+			},
+		},
+	})
+}
+
+// testUnitKubernetesV1PutCluster seeds a cluster with a control plane and
+// two worker node groups.
+func testUnitKubernetesV1PutCluster(server *mockcloud.Server) string {
+	clusterID := server.Put("clusters", map[string]interface{}{
+		"name":          "cluster_1",
+		"status":        "CREATE_COMPLETE",
+		"status_reason": "",
+		"coe_version":   "v1.28.3",
+		"labels":        map[string]interface{}{},
+	})
+	// The container-infra API identifies objects by uuid.
+	server.Update("clusters", clusterID, map[string]interface{}{"uuid": clusterID})
+
+	for _, ng := range []map[string]interface{}{
+		{"name": "default-master", "role": "master"},
+		{"name": "default-worker", "role": "worker"},
+		{"name": "workers-2", "role": "worker"},
+	} {
+		ng["cluster_id"] = clusterID
+		ng["version"] = "v1.28.3"
+		ng["status"] = "CREATE_COMPLETE"
+		ng["node_count"] = 1
+		ng["labels"] = map[string]interface{}{}
+		id := server.Put("nodegroups", ng)
+		server.Update("nodegroups", id, map[string]interface{}{"uuid": id})
+	}
+
+	return clusterID
+}
+
+func testUnitKubernetesV1ClusterUpgradeConfig(clusterID, version, extra string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_kubernetes_cluster_upgrade_v1" "upgrade_1" {
+  cluster_id = "%s"
+  version    = "%s"
+  %s
+}
+`, clusterID, version, extra)
+}
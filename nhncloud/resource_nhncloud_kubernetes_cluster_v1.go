@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/clusters"
@@ -30,6 +31,7 @@ func resourceKubernetesClusterV1() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKubernetesClusterV1Create,
 		ReadContext:   resourceKubernetesClusterV1Read,
+		UpdateContext: resourceKubernetesClusterV1Update,
 		DeleteContext: resourceKubernetesClusterV1Delete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -37,6 +39,7 @@ func resourceKubernetesClusterV1() *schema.Resource {
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
@@ -211,10 +214,50 @@ func resourceKubernetesClusterV1() *schema.Resource {
 				Computed: true,
 			},
 
+			"api_endpoint_ipacl": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "ALLOW",
+							ValidateFunc: validation.StringInSlice([]string{"ALLOW", "DENY"}, false),
+						},
+
+						"target": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"cidr_address": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.IsCIDR,
+									},
+
+									"description": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
 			"addons": {
 				Type:     schema.TypeList,
-				Required: true,
-				ForceNew: true,
+				Optional: true,
+				Computed: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name": {
@@ -314,30 +357,47 @@ func resourceKubernetesClusterV1Create(ctx context.Context, d *schema.ResourceDa
 
 	log.Printf("[DEBUG] Created nhncloud_kubernetes_cluster_v1 %s", s.UUID)
 
+	if v, ok := d.GetOk("api_endpoint_ipacl"); ok {
+		if err := kubernetesClusterV1UpdateIPACL(ctx, d, kubernetesClient, v.([]interface{}), schema.TimeoutCreate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceKubernetesClusterV1Read(ctx, d, meta)
 }
 
 func getKubernetesDefaultNodegroupNodeCount(kubernetesClient *gophercloud.ServiceClient, clusterID string) (int, error) {
+	ng, err := getKubernetesDefaultWorkerNodegroup(kubernetesClient, clusterID)
+	if err != nil {
+		return 0, err
+	}
+
+	return ng.NodeCount, nil
+}
+
+// getKubernetesDefaultWorkerNodegroup returns the worker node group created
+// together with the cluster.
+func getKubernetesDefaultWorkerNodegroup(kubernetesClient *gophercloud.ServiceClient, clusterID string) (*nodegroups.Nodegroup, error) {
 	kubernetesClient.Microversion = kubernetesV1NodeGroupMinMicroversion
 	listOpts := nodegroups.ListOpts{}
 
 	allPages, err := nodegroups.List(kubernetesClient, clusterID, listOpts).AllPages()
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	ngs, err := nodegroups.ExtractNodegroups(allPages)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	for _, ng := range ngs {
 		if ng.IsDefault && ng.Role != "master" {
-			return ng.NodeCount, nil
+			return &ng, nil
 		}
 	}
 
-	return 0, fmt.Errorf("Default worker nodegroup not found")
+	return nil, fmt.Errorf("Default worker nodegroup not found")
 }
 
 func resourceKubernetesClusterV1Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -402,6 +462,18 @@ func resourceKubernetesClusterV1Read(_ context.Context, d *schema.ResourceData,
 		d.Set("kubeconfig", map[string]interface{}{})
 	}
 
+	// The IP access control is only read back once it is managed, reading it
+	// needs a separate request.
+	if _, ok := d.GetOk("api_endpoint_ipacl"); ok {
+		ipacl, err := kubernetesClusterV1GetIPACL(kubernetesClient, d.Id())
+		if err != nil {
+			return diag.Errorf("Error retrieving nhncloud_kubernetes_cluster_v1 %s api_endpoint_ipacl: %s", d.Id(), err)
+		}
+		if err := d.Set("api_endpoint_ipacl", flattenKubernetesClusterV1IPACL(ipacl)); err != nil {
+			log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_cluster_v1 api_endpoint_ipacl: %s", err)
+		}
+	}
+
 	rawConfig := d.GetRawConfig()
 	if !rawConfig.IsNull() && rawConfig.Type().HasAttribute("labels") {
 		configLabelsAttr := rawConfig.GetAttr("labels")
@@ -497,6 +569,113 @@ func resourceKubernetesClusterV1Read(_ context.Context, d *schema.ResourceData,
 	return nil
 }
 
+func resourceKubernetesClusterV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud kubernetes client: %s", err)
+	}
+
+	if d.HasChange("addons") {
+		// Read takes addons from the configuration, so keep the prior addons
+		// in state until every addon was updated, or a failed update would
+		// never be retried.
+		d.Partial(true)
+
+		oldAddonsRaw, newAddonsRaw := d.GetChange("addons")
+		oldAddons := expandKubernetesClusterV1Addons(oldAddonsRaw.([]interface{}))
+		newAddons := expandKubernetesClusterV1Addons(newAddonsRaw.([]interface{}))
+
+		// Addons removed from the list are left installed, so that they can
+		// be handed over to nhncloud_kubernetes_addon_v1.
+		for _, addon := range kubernetesClusterV1AddedAddons(oldAddons, newAddons) {
+			log.Printf("[DEBUG] Installing addon %s %s in nhncloud_kubernetes_cluster_v1 %s", addon.Name, addon.Version, d.Id())
+			if err := kubernetesAddonV1Install(kubernetesClient, d.Id(), addon); err != nil {
+				return diag.Errorf("Error installing addon %s in nhncloud_kubernetes_cluster_v1 %s: %s", addon.Name, d.Id(), err)
+			}
+
+			if err := kubernetesAddonV1WaitForStatus(ctx, kubernetesClient, d.Id(), addon.Name, schema.TimeoutUpdate, d); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		for _, addon := range kubernetesClusterV1ChangedAddons(oldAddons, newAddons) {
+			log.Printf("[DEBUG] Updating addon %s of nhncloud_kubernetes_cluster_v1 %s to %s", addon.Name, d.Id(), addon.Version)
+			if err := kubernetesAddonV1Update(kubernetesClient, d.Id(), addon); err != nil {
+				return diag.Errorf("Error updating addon %s of nhncloud_kubernetes_cluster_v1 %s: %s", addon.Name, d.Id(), err)
+			}
+
+			if err := kubernetesAddonV1WaitForStatus(ctx, kubernetesClient, d.Id(), addon.Name, schema.TimeoutUpdate, d); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.Partial(false)
+	}
+
+	if d.HasChange("api_endpoint_ipacl") {
+		if err := kubernetesClusterV1UpdateIPACL(ctx, d, kubernetesClient, d.Get("api_endpoint_ipacl").([]interface{}), schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("node_count") {
+		ng, err := getKubernetesDefaultWorkerNodegroup(kubernetesClient, d.Id())
+		if err != nil {
+			return diag.Errorf("Error retrieving the default worker node group of nhncloud_kubernetes_cluster_v1 %s: %s", d.Id(), err)
+		}
+
+		nodeCount := d.Get("node_count").(int)
+		resizeOpts := clusters.ResizeOpts{
+			NodeGroup: ng.UUID,
+			NodeCount: &nodeCount,
+		}
+
+		log.Printf("[DEBUG] Resizing the default worker node group of nhncloud_kubernetes_cluster_v1 %s to %d nodes", d.Id(), nodeCount)
+		_, err = clusters.Resize(kubernetesClient, d.Id(), resizeOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error resizing nhncloud_kubernetes_cluster_v1 %s: %s", d.Id(), err)
+		}
+
+		if err := kubernetesClusterV1WaitForUpdate(ctx, d, kubernetesClient, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKubernetesClusterV1Read(ctx, d, meta)
+}
+
+// kubernetesClusterV1UpdateIPACL sets the IP access control of the API
+// endpoint and waits for the cluster to apply it.
+func kubernetesClusterV1UpdateIPACL(ctx context.Context, d *schema.ResourceData, client *gophercloud.ServiceClient, v []interface{}, timeout string) error {
+	ipacl := expandKubernetesClusterV1IPACL(v)
+
+	log.Printf("[DEBUG] Updating nhncloud_kubernetes_cluster_v1 %s api_endpoint_ipacl: %#v", d.Id(), ipacl)
+	if err := kubernetesClusterV1SetIPACL(client, d.Id(), ipacl); err != nil {
+		return fmt.Errorf("Error updating nhncloud_kubernetes_cluster_v1 %s api_endpoint_ipacl: %s", d.Id(), err)
+	}
+
+	return kubernetesClusterV1WaitForUpdate(ctx, d, client, timeout)
+}
+
+func kubernetesClusterV1WaitForUpdate(ctx context.Context, d *schema.ResourceData, client *gophercloud.ServiceClient, timeout string) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"UPDATE_IN_PROGRESS"},
+		Target:     []string{"UPDATE_COMPLETE", "CREATE_COMPLETE"},
+		Refresh:    kubernetesClusterV1StateRefreshFunc(client, d.Id()),
+		Timeout:    d.Timeout(timeout),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	_, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for nhncloud_kubernetes_cluster_v1 %s to be updated: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
 func resourceKubernetesClusterV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	config := meta.(*Config)
 	kubernetesClient, err := config.ContainerInfraV1Client(GetRegion(d, config))
@@ -9,8 +9,10 @@ import (
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/nhn-cloud/nhncloud.gophercloud/nhncloud/kubernetes/v1/nodegroups"
 )
@@ -31,6 +33,11 @@ func resourceKubernetesNodeGroupV1() *schema.Resource {
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			resourceKubernetesNodeGroupV1CustomizeDiff,
+			kubernetesNodeGroupV1TaintsCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"region": {
 				Type:     schema.TypeString,
@@ -79,7 +86,6 @@ func resourceKubernetesNodeGroupV1() *schema.Resource {
 						"availability_zone",
 						"boot_volume_type",
 						"boot_volume_size",
-						"ca_enable",
 					}
 					for _, key := range requiredLabels {
 						if _, exists := labels[key]; !exists {
@@ -91,9 +97,99 @@ func resourceKubernetesNodeGroupV1() *schema.Resource {
 			},
 
 			"node_count": {
-				Type:     schema.TypeInt,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: kubernetesNodeGroupV1NodeCountDiffSuppress,
+			},
+
+			"autoscale": {
+				Type:     schema.TypeList,
 				Optional: true,
 				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"min_node_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"max_node_count": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"scale_down_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+
+						"scale_down_unneeded_time": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+
+						"scale_down_util_threshold": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      50,
+							ValidateFunc: validation.IntBetween(1, 100),
+						},
+
+						"scale_down_delay_after_add": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      10,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+
+			"k8s_node_labels": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"k8s_taints": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      kubernetesNodeGroupV1TaintHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+
+						"value": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"effect": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								"NoSchedule", "PreferNoSchedule", "NoExecute",
+							}, false),
+						},
+					},
+				},
 			},
 
 			"image_id": {
@@ -162,6 +258,11 @@ func resourceKubernetesNodeGroupV1Create(ctx context.Context, d *schema.Resource
 		return diag.FromErr(err)
 	}
 
+	// The autoscale block takes precedence over raw ca_* labels.
+	for label, val := range expandKubernetesNodeGroupV1Autoscale(d.Get("autoscale").([]interface{})) {
+		labels[label] = fmt.Sprintf("%v", val)
+	}
+
 	createOpts := nodegroups.CreateOpts{
 		Name:     d.Get("name").(string),
 		Labels:   labels,
@@ -208,6 +309,18 @@ func resourceKubernetesNodeGroupV1Create(ctx context.Context, d *schema.Resource
 
 	log.Printf("[DEBUG] Created nhncloud_kubernetes_nodegroup_v1 %s", nodeGroup.UUID)
 
+	// Kubernetes node labels and taints are not part of the create request,
+	// they are applied to the nodes once the node group exists.
+	updateOpts, err := kubernetesNodeGroupV1K8sNodeUpdateOpts(d, kubernetesClient, clusterID, nodeGroup.UUID, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(updateOpts) > 0 {
+		if err := kubernetesNodeGroupV1UpdateK8sNodes(ctx, d, kubernetesClient, clusterID, nodeGroup.UUID, updateOpts, schema.TimeoutCreate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceKubernetesNodeGroupV1Read(ctx, d, meta)
 }
 
@@ -225,7 +338,8 @@ func resourceKubernetesNodeGroupV1Read(_ context.Context, d *schema.ResourceData
 		return diag.FromErr(CheckDeleted(d, err, "Error parsing ID of nhncloud_kubernetes_nodegroup_v1"))
 	}
 
-	nodeGroup, err := nodegroups.Get(kubernetesClient, clusterID, nodeGroupID).Extract()
+	getResult := nodegroups.Get(kubernetesClient, clusterID, nodeGroupID)
+	nodeGroup, err := getResult.Extract()
 	if err != nil {
 		if _, ok := err.(gophercloud.ErrDefault403); ok {
 			if nodeGroupID == "default-master" {
@@ -254,7 +368,17 @@ func resourceKubernetesNodeGroupV1Read(_ context.Context, d *schema.ResourceData
 				filteredLabels[key] = val.AsString()
 			}
 
+			// The autoscale block owns the ca_* labels once it is set, so
+			// changing it does not show up as a labels change.
+			autoscaleInConfig := false
+			if rawConfig.Type().HasAttribute("autoscale") {
+				autoscaleAttr := rawConfig.GetAttr("autoscale")
+				autoscaleInConfig = !autoscaleAttr.IsKnown() || (!autoscaleAttr.IsNull() && autoscaleAttr.LengthInt() > 0)
+			}
 			for key, apiVal := range apiLabels {
+				if autoscaleInConfig && strings.HasPrefix(key, "ca_") {
+					continue
+				}
 				if _, existsInConfig := filteredLabels[key]; existsInConfig {
 					filteredLabels[key] = flattenKubernetesV1LabelValue(apiVal)
 				}
@@ -282,6 +406,19 @@ func resourceKubernetesNodeGroupV1Read(_ context.Context, d *schema.ResourceData
 	d.Set("status_reason", nodeGroup.StatusReason)
 	d.Set("version", nodeGroup.Version)
 
+	if err := d.Set("autoscale", flattenKubernetesNodeGroupV1Autoscale(nodeGroup.Labels)); err != nil {
+		log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_nodegroup_v1 autoscale: %s", err)
+	}
+
+	var k8sNodes kubernetesNodeGroupV1K8sNodes
+	if err := getResult.ExtractInto(&k8sNodes); err != nil {
+		return diag.Errorf("Error extracting nhncloud_kubernetes_nodegroup_v1 %s node labels and taints: %s", d.Id(), err)
+	}
+	d.Set("k8s_node_labels", flattenKubernetesNodeGroupV1K8sNodeLabels(k8sNodes.Labels, d.Get("k8s_node_labels").(map[string]interface{})))
+	if err := d.Set("k8s_taints", flattenKubernetesNodeGroupV1Taints(k8sNodes.Taints)); err != nil {
+		log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_nodegroup_v1 k8s_taints: %s", err)
+	}
+
 	if err := d.Set("created_at", nodeGroup.CreatedAt.Format(time.RFC3339)); err != nil {
 		log.Printf("[DEBUG] Unable to set nhncloud_kubernetes_nodegroup_v1 created_at: %s", err)
 	}
@@ -320,6 +457,16 @@ func resourceKubernetesNodeGroupV1Update(ctx context.Context, d *schema.Resource
 			updateOpts, "max_node_count", maxNodeCount)
 	}
 
+	k8sNodeUpdateOpts, err := kubernetesNodeGroupV1K8sNodeUpdateOpts(d, kubernetesClient, clusterID, nodeGroupID, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(k8sNodeUpdateOpts) > 0 {
+		if err := kubernetesNodeGroupV1UpdateK8sNodes(ctx, d, kubernetesClient, clusterID, nodeGroupID, k8sNodeUpdateOpts, schema.TimeoutUpdate); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if len(updateOpts) > 0 {
 		log.Printf(
 			"[DEBUG] Updating nhncloud_kubernetes_nodegroup_v1 %s with options: %#v", d.Id(), updateOpts)
@@ -330,6 +477,33 @@ func resourceKubernetesNodeGroupV1Update(ctx context.Context, d *schema.Resource
 		}
 	}
 
+	if d.HasChange("autoscale") {
+		autoscale := expandKubernetesNodeGroupV1Autoscale(d.Get("autoscale").([]interface{}))
+		if autoscale != nil {
+			log.Printf(
+				"[DEBUG] Updating nhncloud_kubernetes_nodegroup_v1 %s autoscale with options: %#v", d.Id(), autoscale)
+
+			err = kubernetesNodeGroupV1Autoscale(kubernetesClient, clusterID, nodeGroupID, autoscale)
+			if err != nil {
+				return diag.Errorf("Error updating nhncloud_kubernetes_nodegroup_v1 %s autoscale: %s", d.Id(), err)
+			}
+
+			stateConf := &resource.StateChangeConf{
+				Pending:      []string{"UPDATE_IN_PROGRESS"},
+				Target:       []string{"UPDATE_COMPLETE", "CREATE_COMPLETE"},
+				Refresh:      kubernetesNodeGroupV1StateRefreshFunc(kubernetesClient, clusterID, nodeGroupID),
+				Timeout:      d.Timeout(schema.TimeoutUpdate),
+				Delay:        10 * time.Second,
+				PollInterval: 10 * time.Second,
+			}
+			_, err = stateConf.WaitForStateContext(ctx)
+			if err != nil {
+				return diag.Errorf(
+					"Error waiting for nhncloud_kubernetes_nodegroup_v1 %s autoscale to be updated: %s", d.Id(), err)
+			}
+		}
+	}
+
 	return resourceKubernetesNodeGroupV1Read(ctx, d, meta)
 }
 
@@ -368,6 +542,128 @@ func resourceKubernetesNodeGroupV1Delete(ctx context.Context, d *schema.Resource
 	return nil
 }
 
+// kubernetesNodeGroupV1K8sNodeUpdateOpts returns the update options for the
+// Kubernetes node labels and taints. With onlyChanges, unchanged ones are
+// left out.
+func kubernetesNodeGroupV1K8sNodeUpdateOpts(d *schema.ResourceData, client *gophercloud.ServiceClient, clusterID, nodeGroupID string, onlyChanges bool) ([]nodegroups.UpdateOptsBuilder, error) {
+	updateOpts := []nodegroups.UpdateOptsBuilder{}
+
+	if labels := d.Get("k8s_node_labels").(map[string]interface{}); (onlyChanges && d.HasChange("k8s_node_labels")) || (!onlyChanges && len(labels) > 0) {
+		// The labels are replaced as a whole, so the ones that the system
+		// added to the nodes are sent back.
+		current, err := kubernetesNodeGroupV1GetK8sNodes(client, clusterID, nodeGroupID)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving nhncloud_kubernetes_nodegroup_v1 %s node labels: %s", nodeGroupID, err)
+		}
+
+		o, n := d.GetChange("k8s_node_labels")
+		updateOpts = append(updateOpts, nodegroups.UpdateOpts{
+			Op:    nodegroups.ReplaceOp,
+			Path:  "/k8s_node_labels",
+			Value: expandKubernetesNodeGroupV1K8sNodeLabels(current.Labels, o.(map[string]interface{}), n.(map[string]interface{})),
+		})
+	}
+
+	if taints := d.Get("k8s_taints").(*schema.Set).List(); (onlyChanges && d.HasChange("k8s_taints")) || (!onlyChanges && len(taints) > 0) {
+		updateOpts = append(updateOpts, nodegroups.UpdateOpts{
+			Op:    nodegroups.ReplaceOp,
+			Path:  "/k8s_taints",
+			Value: expandKubernetesNodeGroupV1Taints(taints),
+		})
+	}
+
+	return updateOpts, nil
+}
+
+// kubernetesNodeGroupV1UpdateK8sNodes applies Kubernetes node labels and
+// taints in place and waits for the nodes to be updated.
+func kubernetesNodeGroupV1UpdateK8sNodes(ctx context.Context, d *schema.ResourceData, client *gophercloud.ServiceClient, clusterID, nodeGroupID string, updateOpts []nodegroups.UpdateOptsBuilder, timeout string) error {
+	log.Printf(
+		"[DEBUG] Updating nhncloud_kubernetes_nodegroup_v1 %s node labels and taints with options: %#v", nodeGroupID, updateOpts)
+
+	_, err := nodegroups.Update(client, clusterID, nodeGroupID, updateOpts).Extract()
+	if err != nil {
+		return fmt.Errorf("Error updating nhncloud_kubernetes_nodegroup_v1 %s node labels and taints: %s", nodeGroupID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{"UPDATE_IN_PROGRESS"},
+		Target:       []string{"UPDATE_COMPLETE", "CREATE_COMPLETE"},
+		Refresh:      kubernetesNodeGroupV1StateRefreshFunc(client, clusterID, nodeGroupID),
+		Timeout:      d.Timeout(timeout),
+		Delay:        10 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for nhncloud_kubernetes_nodegroup_v1 %s node labels and taints to be updated: %s", nodeGroupID, err)
+	}
+
+	return nil
+}
+
+// resourceKubernetesNodeGroupV1CustomizeDiff requires a new node group to
+// configure the cluster autoscaler, either with the autoscale block or with
+// the ca_enable label.
+func resourceKubernetesNodeGroupV1CustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() != "" {
+		return nil
+	}
+
+	if _, ok := diff.GetOk("autoscale"); ok {
+		return nil
+	}
+
+	if !diff.NewValueKnown("labels") {
+		return nil
+	}
+
+	if _, ok := diff.Get("labels").(map[string]interface{})["ca_enable"]; !ok {
+		return fmt.Errorf("either the autoscale block or the ca_enable label is required")
+	}
+
+	return nil
+}
+
+// kubernetesNodeGroupV1TaintsCustomizeDiff rejects k8s_taints blocks with the
+// same key and effect, which Kubernetes treats as the same taint. They are
+// checked in the configuration, as the set keeps only one of them.
+func kubernetesNodeGroupV1TaintsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	rawConfig := diff.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	taints := rawConfig.GetAttr("k8s_taints")
+	if taints.IsNull() || !taints.IsKnown() {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for it := taints.ElementIterator(); it.Next(); {
+		_, taint := it.Element()
+		key, effect := taint.GetAttr("key"), taint.GetAttr("effect")
+		if key.IsNull() || !key.IsKnown() || effect.IsNull() || !effect.IsKnown() {
+			continue
+		}
+
+		id := key.AsString() + ":" + effect.AsString()
+		if seen[id] {
+			return fmt.Errorf("k8s_taints has more than one taint with key %q and effect %q", key.AsString(), effect.AsString())
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// kubernetesNodeGroupV1NodeCountDiffSuppress ignores node_count changes while
+// the cluster autoscaler decides the size of the node group.
+func kubernetesNodeGroupV1NodeCountDiffSuppress(_, _, _ string, d *schema.ResourceData) bool {
+	return d.Id() != "" && d.Get("autoscale.0.enabled").(bool)
+}
+
 func parseNodeGroupID(id string) (string, string, error) {
 	idParts := strings.Split(id, "/")
 	if len(idParts) < 2 {
@@ -2,6 +2,7 @@ package nhncloud
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"testing"
 
@@ -76,6 +77,42 @@ func TestAccKubernetesV1NodeGroup_basic(t *testing.T) {
 	})
 }
 
+func TestUnitKubernetesV1NodeGroup_duplicateTaints(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_kubernetes_nodegroup_v1" "nodegroup_1" {
+  cluster_id = "cluster_1"
+  name       = "nodegroup_1"
+
+  autoscale {
+    enabled = false
+  }
+
+  k8s_taints {
+    key    = "dedicated"
+    value  = "gpu"
+    effect = "NoSchedule"
+  }
+
+  k8s_taints {
+    key    = "dedicated"
+    value  = "cpu"
+    effect = "NoSchedule"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`more than one taint with key "dedicated" and effect "NoSchedule"`),
+			},
+		},
+	})
+}
+
 func TestAccKubernetesV1NodeGroup_mergeLabels(t *testing.T) {
 	var nodeGroup nodegroups.Nodegroup
 
@@ -149,12 +149,28 @@ func resourceListenerV2() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"keepalive_timeout": {
 				Type:     schema.TypeInt,
 				Optional: true,
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
@@ -236,7 +252,7 @@ func resourceListenerV2Read(ctx context.Context, d *schema.ResourceData, meta in
 		d.Set("default_tls_container_ref", listener.DefaultTlsContainerRef)
 		d.Set("allowed_cidrs", listener.AllowedCIDRs)
 		d.Set("region", GetRegion(d, config))
-		d.Set("tags", listener.Tags)
+		expandObjectReadTags(d, listener.Tags)
 		d.Set("keepalive_timeout", listener.KeepaliveTimeout)
 
 		// Required by import.
@@ -38,6 +38,12 @@ func resourceLoadBalancerV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -124,6 +130,20 @@ func resourceLoadBalancerV2() *schema.Resource {
 				Set:      schema.HashString,
 			},
 
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
 			"loadbalancer_type": {
 				Type:         schema.TypeString,
 				Default:      "shared",
@@ -131,11 +151,16 @@ func resourceLoadBalancerV2() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"shared", "dedicated"}, true),
 			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
 func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	lbClient, err := chooseLBV2Client(d, config)
 	if err != nil {
 		return diag.Errorf("Error creating nhncloud networking client: %s", err)
@@ -174,10 +199,7 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 			createOpts.AvailabilityZone = aZ
 		}
 
-		if v, ok := d.GetOk("tags"); ok {
-			tags := v.(*schema.Set).List()
-			createOpts.Tags = expandToStringSlice(tags)
-		}
+		createOpts.Tags = expandObjectTags(d, config.DefaultTags)
 
 		log.Printf("[DEBUG][Octavia] nhncloud_lb_loadbalancer_v2 create options: %#v", createOpts)
 		lb, err := octavialoadbalancers.Create(lbClient, createOpts).Extract()
@@ -231,7 +253,10 @@ func resourceLoadBalancerV2Create(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	lbClient, err := chooseLBV2Client(d, config)
 	if err != nil {
 		return diag.Errorf("Error creating nhncloud networking client: %s", err)
@@ -259,7 +284,7 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, met
 		d.Set("loadbalancer_provider", lb.Provider)
 		d.Set("availability_zone", lb.AvailabilityZone)
 		d.Set("region", GetRegion(d, config))
-		d.Set("tags", lb.Tags)
+		expandObjectReadTags(d, lb.Tags)
 		d.Set("loadbalancer_type", lb.LoadBalancerType)
 		d.Set("ipacl_group_action", lb.IpACLGroupAction)
 		vipPortID = lb.VipPortID
@@ -301,7 +326,10 @@ func resourceLoadBalancerV2Read(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	lbClient, err := chooseLBV2Client(d, config)
 	if err != nil {
 		return diag.Errorf("Error creating nhncloud networking client: %s", err)
@@ -356,7 +384,10 @@ func resourceLoadBalancerV2Update(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceLoadBalancerV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	lbClient, err := chooseLBV2Client(d, config)
 	if err != nil {
 		return diag.Errorf("Error creating nhncloud networking client: %s", err)
@@ -543,3 +543,45 @@ resource "nhncloud_lb_loadbalancer_v2" "loadbalancer_1" {
   }
 }
 `
+
+func TestUnitLBV2LoadBalancer_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	subnetID := server.List("vpcsubnets")[0]["id"].(string)
+	secgroupID := server.List("security-groups")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_lb_loadbalancer_v2", "loadbalancers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitLbV2LoadBalancerConfig(subnetID, "loadbalancer_1", secgroupID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "name", "loadbalancer_1"),
+					resource.TestCheckResourceAttr("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "vip_subnet_id", subnetID),
+					resource.TestCheckResourceAttrSet("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "vip_address"),
+					resource.TestCheckResourceAttrSet("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "vip_port_id"),
+					resource.TestCheckResourceAttr("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "security_group_ids.#", "1"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_lb_loadbalancer_v2.loadbalancer_1", "loadbalancers", "provisioning_status", "ACTIVE"),
+				),
+			},
+			{
+				Config: providerConfig + testUnitLbV2LoadBalancerConfig(subnetID, "loadbalancer_1_updated", secgroupID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_lb_loadbalancer_v2.loadbalancer_1", "name", "loadbalancer_1_updated"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_lb_loadbalancer_v2.loadbalancer_1", "loadbalancers", "name", "loadbalancer_1_updated"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitLbV2LoadBalancerConfig(subnetID, name, secgroupID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_lb_loadbalancer_v2" "loadbalancer_1" {
+  name               = "%s"
+  vip_subnet_id      = "%s"
+  security_group_ids = ["%s"]
+}
+`, name, subnetID, secgroupID)
+}
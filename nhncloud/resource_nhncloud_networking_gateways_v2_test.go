@@ -0,0 +1,149 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2Gateways_topology(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	externalNetworkID := server.Put("vpcs", map[string]interface{}{
+		"name":     "Public Network",
+		"cidrv4":   "133.186.0.0/16",
+		"state":    "available",
+		"external": true,
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_internet_gateway_v2", "internetgateways"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_nat_gateway_v2", "natgateways"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_service_gateway_v2", "servicegateways"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2GatewaysConfig(externalNetworkID, "nat_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_internet_gateway_v2.igw_1", "external_network_id", externalNetworkID),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_internet_gateway_v2.igw_1", "internetgateways", "state", "available"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_networking_nat_gateway_v2.nat_1", "subnet_id",
+						"nhncloud_networking_vpcsubnet_v2.subnet_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_networking_nat_gateway_v2.nat_1", "state", "ACTIVE"),
+					resource.TestCheckResourceAttr("nhncloud_networking_service_gateway_v2.sgw_1", "ip_address", "10.30.1.4"),
+					resource.TestCheckResourceAttr("nhncloud_networking_service_gateway_v2.sgw_1", "state", "ACTIVE"),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_networking_internet_gateway_v2.igw_1", "id",
+						"nhncloud_networking_internet_gateway_v2.igw_1", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_networking_nat_gateway_v2.nat_1", "id",
+						"nhncloud_networking_nat_gateway_v2.nat_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_service_gateway_v2.sgw_1", "service_endpoint_id", "endpoint-1"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_networking_internet_gateway_v2.igw_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// The gateway was attached after it was read.
+				ImportStateVerifyIgnore: []string{"routingtable_id", "state"},
+			},
+			{
+				ResourceName:      "nhncloud_networking_nat_gateway_v2.nat_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "nhncloud_networking_service_gateway_v2.sgw_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2GatewaysConfig(externalNetworkID, "nat_renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_nat_gateway_v2.nat_1", "name", "nat_renamed"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_nat_gateway_v2.nat_1", "natgateways", "name", "nat_renamed"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2InternetGateway_notExternal(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	vpcID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "nhncloud_networking_internet_gateway_v2" "igw_1" {
+  name                = "igw_1"
+  external_network_id = "%s"
+}
+`, vpcID),
+				ExpectError: regexp.MustCompile("is not an external network"),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2GatewaysConfig(externalNetworkID, natName string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.30.0.0/16"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_1" {
+  name   = "subnet_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.30.1.0/24"
+}
+
+resource "nhncloud_networking_routingtable_v2" "routingtable_1" {
+  name   = "routingtable_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+}
+
+resource "nhncloud_networking_internet_gateway_v2" "igw_1" {
+  name                = "igw_1"
+  external_network_id = "%s"
+}
+
+resource "nhncloud_networking_routingtable_attach_gateway_v2" "attach_1" {
+  routingtable_id = nhncloud_networking_routingtable_v2.routingtable_1.id
+  gateway_id      = nhncloud_networking_internet_gateway_v2.igw_1.id
+}
+
+resource "nhncloud_networking_nat_gateway_v2" "nat_1" {
+  name      = "%s"
+  vpc_id    = nhncloud_networking_vpc_v2.vpc_1.id
+  subnet_id = nhncloud_networking_vpcsubnet_v2.subnet_1.id
+}
+
+resource "nhncloud_networking_service_gateway_v2" "sgw_1" {
+  name                = "sgw_1"
+  vpc_id              = nhncloud_networking_vpc_v2.vpc_1.id
+  subnet_id           = nhncloud_networking_vpcsubnet_v2.subnet_1.id
+  service_endpoint_id = "endpoint-1"
+}
+
+data "nhncloud_networking_internet_gateway_v2" "igw_1" {
+  name = nhncloud_networking_internet_gateway_v2.igw_1.name
+}
+
+data "nhncloud_networking_nat_gateway_v2" "nat_1" {
+  vpc_id = nhncloud_networking_nat_gateway_v2.nat_1.vpc_id
+}
+
+data "nhncloud_networking_service_gateway_v2" "sgw_1" {
+  id = nhncloud_networking_service_gateway_v2.sgw_1.id
+}
+`, externalNetworkID, natName)
+}
@@ -0,0 +1,186 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetworkingInternetGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingInternetGatewayV2Create,
+		ReadContext:   resourceNetworkingInternetGatewayV2Read,
+		DeleteContext: resourceNetworkingInternetGatewayV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"external_network_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"routingtable_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"migrate_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingInternetGatewayV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	createOpts := internetGatewayCreateOpts{
+		Name:              d.Get("name").(string),
+		ExternalNetworkID: d.Get("external_network_id").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_internet_gateway_v2 create options: %#v", createOpts)
+	gateway, err := internetGatewayCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_internet_gateway_v2: %s", err)
+	}
+
+	d.SetId(gateway.ID)
+
+	log.Printf("[DEBUG] Waiting for nhncloud_networking_internet_gateway_v2 %s to become ready.", gateway.ID)
+
+	// An internet gateway is "unavailable" until it is attached to a routing
+	// table, so both states mean the gateway was created.
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"", "migrating"},
+		Target:     []string{"available", "unavailable"},
+		Refresh:    networkingInternetGatewayV2StateRefreshFunc(networkingClient, gateway.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_internet_gateway_v2 %s to become ready: %s", gateway.ID, err)
+	}
+
+	log.Printf("[DEBUG] Created nhncloud_networking_internet_gateway_v2 %s: %#v", gateway.ID, gateway)
+	return resourceNetworkingInternetGatewayV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingInternetGatewayV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	gateway, err := internetGatewayGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_internet_gateway_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_internet_gateway_v2 %s: %#v", d.Id(), gateway)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", gateway.Name)
+	d.Set("external_network_id", gateway.ExternalNetworkID)
+	d.Set("routingtable_id", gateway.RoutingtableID)
+	d.Set("state", gateway.State)
+	d.Set("migrate_status", gateway.MigrateStatus)
+	d.Set("tenant_id", gateway.TenantID)
+	d.Set("create_time", gateway.CreateTime)
+
+	return nil
+}
+
+func resourceNetworkingInternetGatewayV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if err := internetGatewayDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_internet_gateway_v2"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "unavailable", "migrating"},
+		Target:     []string{"DELETED"},
+		Refresh:    networkingInternetGatewayV2StateRefreshFunc(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_internet_gateway_v2 %s to delete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,121 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetworkingNetworkACLBindingV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingNetworkACLBindingV2Create,
+		ReadContext:   resourceNetworkingNetworkACLBindingV2Read,
+		DeleteContext: resourceNetworkingNetworkACLBindingV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingNetworkACLBindingV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	createOpts := networkACLBindingCreateOpts{
+		ACLID:    d.Get("acl_id").(string),
+		SubnetID: d.Get("subnet_id").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_network_acl_binding_v2 create options: %#v", createOpts)
+	binding, err := networkACLBindingCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_network_acl_binding_v2: %s", err)
+	}
+
+	d.SetId(binding.ID)
+
+	log.Printf("[DEBUG] Created nhncloud_networking_network_acl_binding_v2 %s: %#v", binding.ID, binding)
+	return resourceNetworkingNetworkACLBindingV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingNetworkACLBindingV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	binding, err := networkACLBindingGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_network_acl_binding_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_network_acl_binding_v2 %s: %#v", d.Id(), binding)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("acl_id", binding.ACLID)
+	d.Set("subnet_id", binding.SubnetID)
+	d.Set("tenant_id", binding.TenantID)
+
+	return nil
+}
+
+func resourceNetworkingNetworkACLBindingV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if err := networkACLBindingDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_network_acl_binding_v2"))
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetworkingNetworkACLRuleV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingNetworkACLRuleV2Create,
+		ReadContext:   resourceNetworkingNetworkACLRuleV2Read,
+		UpdateContext: resourceNetworkingNetworkACLRuleV2Update,
+		DeleteContext: resourceNetworkingNetworkACLRuleV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"acl_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"order_num": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"policy": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"allow", "deny"}, false),
+			},
+
+			"protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tcp", "udp", "icmp"}, false),
+			},
+
+			"ethertype": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "IPv4",
+				ValidateFunc: validation.StringInSlice([]string{"IPv4"}, false),
+			},
+
+			"src_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "0.0.0.0/0",
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+
+			"dst_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "0.0.0.0/0",
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+
+			"src_port_range_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+
+			"src_port_range_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+
+			"dst_port_range_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+
+			"dst_port_range_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingNetworkACLRuleV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	aclID := d.Get("acl_id").(string)
+	createOpts := expandNetworkACLRuleOpts(d)
+	createOpts.ACLID = aclID
+	createOpts.Ethertype = d.Get("ethertype").(string)
+
+	// Rules of the same ACL are ordered, so they are not changed concurrently.
+	config.MutexKV.Lock(aclID)
+	defer config.MutexKV.Unlock(aclID)
+
+	log.Printf("[DEBUG] nhncloud_networking_network_acl_rule_v2 create options: %#v", createOpts)
+	rule, err := networkACLRuleCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_network_acl_rule_v2: %s", err)
+	}
+
+	d.SetId(rule.ID)
+
+	log.Printf("[DEBUG] Created nhncloud_networking_network_acl_rule_v2 %s: %#v", rule.ID, rule)
+	return resourceNetworkingNetworkACLRuleV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingNetworkACLRuleV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	rule, err := networkACLRuleGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_network_acl_rule_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_network_acl_rule_v2 %s: %#v", d.Id(), rule)
+
+	protocol := ""
+	if rule.Protocol != nil {
+		protocol = *rule.Protocol
+	}
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("acl_id", rule.ACLID)
+	d.Set("description", rule.Description)
+	d.Set("order_num", rule.OrderNum)
+	d.Set("policy", rule.Policy)
+	d.Set("protocol", protocol)
+	d.Set("ethertype", rule.Ethertype)
+	d.Set("src_ip", rule.SrcIP)
+	d.Set("dst_ip", rule.DstIP)
+	d.Set("src_port_range_min", flattenNetworkACLRulePort(rule.SrcPortRangeMin))
+	d.Set("src_port_range_max", flattenNetworkACLRulePort(rule.SrcPortRangeMax))
+	d.Set("dst_port_range_min", flattenNetworkACLRulePort(rule.DstPortRangeMin))
+	d.Set("dst_port_range_max", flattenNetworkACLRulePort(rule.DstPortRangeMax))
+	d.Set("tenant_id", rule.TenantID)
+
+	return nil
+}
+
+func resourceNetworkingNetworkACLRuleV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	aclID := d.Get("acl_id").(string)
+	updateOpts := expandNetworkACLRuleOpts(d)
+
+	config.MutexKV.Lock(aclID)
+	defer config.MutexKV.Unlock(aclID)
+
+	log.Printf("[DEBUG] nhncloud_networking_network_acl_rule_v2 %s update options: %#v", d.Id(), updateOpts)
+	if _, err := networkACLRuleUpdate(networkingClient, d.Id(), updateOpts); err != nil {
+		return diag.Errorf("Error updating nhncloud_networking_network_acl_rule_v2 %s: %s", d.Id(), err)
+	}
+
+	return resourceNetworkingNetworkACLRuleV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingNetworkACLRuleV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	aclID := d.Get("acl_id").(string)
+	config.MutexKV.Lock(aclID)
+	defer config.MutexKV.Unlock(aclID)
+
+	if err := networkACLRuleDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_network_acl_rule_v2"))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetworkingNetworkACLV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingNetworkACLV2Create,
+		ReadContext:   resourceNetworkingNetworkACLV2Read,
+		UpdateContext: resourceNetworkingNetworkACLV2Update,
+		DeleteContext: resourceNetworkingNetworkACLV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingNetworkACLV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	createOpts := networkACLCreateOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_network_acl_v2 create options: %#v", createOpts)
+	acl, err := networkACLCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_network_acl_v2: %s", err)
+	}
+
+	d.SetId(acl.ID)
+
+	log.Printf("[DEBUG] Created nhncloud_networking_network_acl_v2 %s: %#v", acl.ID, acl)
+	return resourceNetworkingNetworkACLV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingNetworkACLV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	acl, err := networkACLGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_network_acl_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_network_acl_v2 %s: %#v", d.Id(), acl)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", acl.Name)
+	d.Set("description", acl.Description)
+	d.Set("tenant_id", acl.TenantID)
+	d.Set("create_time", acl.CreateTime)
+
+	return nil
+}
+
+func resourceNetworkingNetworkACLV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	var updateOpts networkACLUpdateOpts
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts.Name = &name
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_network_acl_v2 %s update options: %#v", d.Id(), updateOpts)
+	if _, err := networkACLUpdate(networkingClient, d.Id(), updateOpts); err != nil {
+		return diag.Errorf("Error updating nhncloud_networking_network_acl_v2 %s: %s", d.Id(), err)
+	}
+
+	return resourceNetworkingNetworkACLV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingNetworkACLV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if err := networkACLDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_network_acl_v2"))
+	}
+
+	return nil
+}
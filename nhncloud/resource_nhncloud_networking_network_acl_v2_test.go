@@ -0,0 +1,135 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2NetworkACL_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy: resource.ComposeTestCheckFunc(
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_network_acl_v2", "acls"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_network_acl_rule_v2", "acl_rules"),
+			testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_network_acl_binding_v2", "aclbindings"),
+		),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2NetworkACLConfig("22"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_v2.acl_1", "name", "acl_1"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_networking_network_acl_rule_v2.allow_ssh", "acl_id",
+						"nhncloud_networking_network_acl_v2.acl_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.allow_ssh", "order_num", "10"),
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.allow_ssh", "dst_port_range_min", "22"),
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.deny_all", "order_num", "100"),
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.deny_all", "protocol", ""),
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.deny_all", "src_ip", "0.0.0.0/0"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_networking_network_acl_binding_v2.binding_1", "subnet_id",
+						"nhncloud_networking_vpcsubnet_v2.subnet_1", "id"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_networking_network_acl_v2.acl_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "nhncloud_networking_network_acl_rule_v2.allow_ssh",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "nhncloud_networking_network_acl_binding_v2.binding_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2NetworkACLConfig("2222"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_network_acl_rule_v2.allow_ssh", "dst_port_range_min", "2222"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_network_acl_rule_v2.allow_ssh", "acl_rules", "dst_port_range_min", "2222"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2NetworkACLRule_duplicateOrder(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_networking_network_acl_v2" "acl_1" {
+  name = "acl_1"
+}
+
+resource "nhncloud_networking_network_acl_rule_v2" "rule_1" {
+  acl_id    = nhncloud_networking_network_acl_v2.acl_1.id
+  policy    = "allow"
+  order_num = 10
+}
+
+resource "nhncloud_networking_network_acl_rule_v2" "rule_2" {
+  acl_id    = nhncloud_networking_network_acl_v2.acl_1.id
+  policy    = "deny"
+  order_num = 10
+}
+`,
+				ExpectError: regexp.MustCompile("already has order 10"),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2NetworkACLConfig(sshPort string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.40.0.0/16"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_1" {
+  name   = "subnet_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.40.1.0/24"
+}
+
+resource "nhncloud_networking_network_acl_v2" "acl_1" {
+  name        = "acl_1"
+  description = "web tier"
+}
+
+resource "nhncloud_networking_network_acl_rule_v2" "allow_ssh" {
+  acl_id             = nhncloud_networking_network_acl_v2.acl_1.id
+  description        = "ssh from the office"
+  protocol           = "tcp"
+  src_ip             = "192.168.0.0/24"
+  dst_port_range_min = %[1]s
+  dst_port_range_max = %[1]s
+  policy             = "allow"
+  order_num          = 10
+}
+
+resource "nhncloud_networking_network_acl_rule_v2" "deny_all" {
+  acl_id    = nhncloud_networking_network_acl_v2.acl_1.id
+  policy    = "deny"
+  order_num = 100
+}
+
+resource "nhncloud_networking_network_acl_binding_v2" "binding_1" {
+  acl_id    = nhncloud_networking_network_acl_v2.acl_1.id
+  subnet_id = nhncloud_networking_vpcsubnet_v2.subnet_1.id
+}
+`, sshPort)
+}
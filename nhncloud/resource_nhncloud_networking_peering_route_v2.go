@@ -0,0 +1,156 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// resourceNetworkingPeeringRouteV2 manages a route of a routing table whose
+// target is a peering. It is a routing table route with the gateway set to
+// the peering.
+func resourceNetworkingPeeringRouteV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingPeeringRouteV2Create,
+		ReadContext:   resourceNetworkingPeeringRouteV2Read,
+		DeleteContext: resourceNetworkingPeeringRouteV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"routingtable_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"peering_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"cidr": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsCIDRNetwork(0, 32),
+			},
+
+			"mask": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingPeeringRouteV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	routingtableID := d.Get("routingtable_id").(string)
+	peeringID := d.Get("peering_id").(string)
+
+	// Fail early with a clear message rather than a route to nowhere.
+	if _, err := peeringGet(networkingClient, peeringID); err != nil {
+		return diag.Errorf("Error getting nhncloud_networking_peering_v2 %s: %s", peeringID, err)
+	}
+
+	createOpts := expandRoutingtableRouteCreateOpts(
+		routingtableID,
+		d.Get("cidr").(string),
+		routingtableRouteGatewayTypeGateway,
+		peeringID,
+	)
+
+	config.MutexKV.Lock(routingtableID)
+	defer config.MutexKV.Unlock(routingtableID)
+
+	log.Printf("[DEBUG] nhncloud_networking_peering_route_v2 create options: %#v", createOpts)
+	route, err := routingtableRouteCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_peering_route_v2: %s", err)
+	}
+
+	d.SetId(route.ID)
+
+	log.Printf("[DEBUG] Created nhncloud_networking_peering_route_v2 %s: %#v", route.ID, route)
+	return resourceNetworkingPeeringRouteV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingPeeringRouteV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	route, err := routingtableRouteGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_peering_route_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_peering_route_v2 %s: %#v", d.Id(), route)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("routingtable_id", route.RoutingtableID)
+	d.Set("peering_id", route.GatewayID)
+	d.Set("cidr", route.CIDR)
+	d.Set("mask", route.Mask)
+	d.Set("tenant_id", route.TenantID)
+
+	return nil
+}
+
+func resourceNetworkingPeeringRouteV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	routingtableID := d.Get("routingtable_id").(string)
+	config.MutexKV.Lock(routingtableID)
+	defer config.MutexKV.Unlock(routingtableID)
+
+	if err := routingtableRouteDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_peering_route_v2"))
+	}
+
+	return nil
+}
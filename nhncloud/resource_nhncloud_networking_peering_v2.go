@@ -0,0 +1,215 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetworkingPeeringV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingPeeringV2Create,
+		ReadContext:   resourceNetworkingPeeringV2Read,
+		UpdateContext: resourceNetworkingPeeringV2Update,
+		DeleteContext: resourceNetworkingPeeringV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"local_vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"remote_vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"local_vpc_cidrv4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"remote_vpc_cidrv4": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingPeeringV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	createOpts := peeringCreateOpts{
+		Name:        d.Get("name").(string),
+		LocalVPCID:  d.Get("local_vpc_id").(string),
+		RemoteVPCID: d.Get("remote_vpc_id").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_peering_v2 create options: %#v", createOpts)
+	p, err := peeringCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_peering_v2: %s", err)
+	}
+
+	d.SetId(p.ID)
+
+	log.Printf("[DEBUG] Waiting for nhncloud_networking_peering_v2 %s to become active.", p.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"", "PENDING", "BUILD"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    networkingPeeringV2StateRefreshFunc(networkingClient, p.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_peering_v2 %s to become active: %s", p.ID, err)
+	}
+
+	log.Printf("[DEBUG] Created nhncloud_networking_peering_v2 %s: %#v", p.ID, p)
+	return resourceNetworkingPeeringV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingPeeringV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	p, err := peeringGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_peering_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_peering_v2 %s: %#v", d.Id(), p)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", p.Name)
+	d.Set("local_vpc_id", p.LocalVPCID)
+	d.Set("remote_vpc_id", p.RemoteVPCID)
+	d.Set("local_vpc_cidrv4", p.LocalVPC.CIDRv4)
+	d.Set("remote_vpc_cidrv4", p.RemoteVPC.CIDRv4)
+	d.Set("state", p.State)
+	d.Set("tenant_id", p.TenantID)
+	d.Set("create_time", p.CreateTime)
+
+	return nil
+}
+
+func resourceNetworkingPeeringV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts := peeringUpdateOpts{Name: &name}
+
+		log.Printf("[DEBUG] nhncloud_networking_peering_v2 %s update options: %#v", d.Id(), updateOpts)
+		if _, err := peeringUpdate(networkingClient, d.Id(), updateOpts); err != nil {
+			return diag.Errorf("Error updating nhncloud_networking_peering_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceNetworkingPeeringV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingPeeringV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if err := peeringDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_peering_v2"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE", "PENDING", "BUILD", "DELETING"},
+		Target:     []string{"DELETED"},
+		Refresh:    networkingPeeringV2StateRefreshFunc(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_peering_v2 %s to delete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
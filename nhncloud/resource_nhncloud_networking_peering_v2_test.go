@@ -0,0 +1,163 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2Peering_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	routingtableID := server.List("routingtables")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_peering_v2", "peerings"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2PeeringConfig(routingtableID, "peering_1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_v2.peering_1", "name", "peering_1"),
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_v2.peering_1", "state", "ACTIVE"),
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_v2.peering_1", "local_vpc_cidrv4", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_v2.peering_1", "remote_vpc_cidrv4", "10.20.0.0/16"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_networking_peering_v2.peering_1", "remote_vpc_id",
+						"nhncloud_networking_vpc_v2.vpc_2", "id"),
+					resource.TestCheckResourceAttrPair(
+						"nhncloud_networking_peering_route_v2.route_1", "peering_id",
+						"nhncloud_networking_peering_v2.peering_1", "id"),
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_route_v2.route_1", "cidr", "10.20.0.0/16"),
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_route_v2.route_1", "mask", "16"),
+					testUnitCheckMockCloudRouteCount(server, routingtableID, 1),
+					resource.TestCheckResourceAttrPair(
+						"data.nhncloud_networking_peering_v2.peering_1", "id",
+						"nhncloud_networking_peering_v2.peering_1", "id"),
+					resource.TestCheckResourceAttr("data.nhncloud_networking_peering_v2.peering_1", "remote_vpc_cidrv4", "10.20.0.0/16"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_networking_peering_v2.peering_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "nhncloud_networking_peering_route_v2.route_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2PeeringConfig(routingtableID, "peering_renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_v2.peering_1", "name", "peering_renamed"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_peering_v2.peering_1", "peerings", "name", "peering_renamed"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2Peering_overlappingCIDR(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.10.0.0/16"
+}
+
+resource "nhncloud_networking_vpc_v2" "vpc_2" {
+  name   = "vpc_2"
+  cidrv4 = "10.10.128.0/17"
+}
+
+resource "nhncloud_networking_peering_v2" "peering_1" {
+  local_vpc_id  = nhncloud_networking_vpc_v2.vpc_1.id
+  remote_vpc_id = nhncloud_networking_vpc_v2.vpc_2.id
+}
+`,
+				ExpectError: regexp.MustCompile("overlaps"),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2Peering_projectID(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	routingtableID := server.List("routingtables")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  project_id = "other-project"
+  name       = "vpc_1"
+  cidrv4     = "10.10.0.0/16"
+}
+
+resource "nhncloud_networking_vpc_v2" "vpc_2" {
+  project_id = "other-project"
+  name       = "vpc_2"
+  cidrv4     = "10.20.0.0/16"
+}
+
+resource "nhncloud_networking_peering_v2" "peering_1" {
+  project_id    = "other-project"
+  local_vpc_id  = nhncloud_networking_vpc_v2.vpc_1.id
+  remote_vpc_id = nhncloud_networking_vpc_v2.vpc_2.id
+}
+
+resource "nhncloud_networking_peering_route_v2" "route_1" {
+  project_id      = "other-project"
+  routingtable_id = "%s"
+  peering_id      = nhncloud_networking_peering_v2.peering_1.id
+  cidr            = nhncloud_networking_peering_v2.peering_1.remote_vpc_cidrv4
+}
+`, routingtableID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_peering_route_v2.route_1", "tenant_id", "other-project"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_peering_route_v2.route_1", "routes", "tenant_id", "other-project"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2PeeringConfig(routingtableID, name string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.10.0.0/16"
+}
+
+resource "nhncloud_networking_vpc_v2" "vpc_2" {
+  name   = "vpc_2"
+  cidrv4 = "10.20.0.0/16"
+}
+
+resource "nhncloud_networking_peering_v2" "peering_1" {
+  name          = "%s"
+  local_vpc_id  = nhncloud_networking_vpc_v2.vpc_1.id
+  remote_vpc_id = nhncloud_networking_vpc_v2.vpc_2.id
+}
+
+resource "nhncloud_networking_peering_route_v2" "route_1" {
+  routingtable_id = "%s"
+  peering_id      = nhncloud_networking_peering_v2.peering_1.id
+  cidr            = nhncloud_networking_peering_v2.peering_1.remote_vpc_cidrv4
+}
+
+data "nhncloud_networking_peering_v2" "peering_1" {
+  local_vpc_id  = nhncloud_networking_peering_v2.peering_1.local_vpc_id
+  remote_vpc_id = nhncloud_networking_peering_v2.peering_1.remote_vpc_id
+}
+`, name, routingtableID)
+}
@@ -23,6 +23,12 @@ func resourceNetworkingRoutingtableAttachGatewayV2() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"routingtable_id": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -38,7 +44,10 @@ func resourceNetworkingRoutingtableAttachGatewayV2() *schema.Resource {
 }
 
 func resourceNetworkingRoutingtableAttachGatewayV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -61,7 +70,10 @@ func resourceNetworkingRoutingtableAttachGatewayV2Create(ctx context.Context, d
 }
 
 func resourceNetworkingRoutingtableAttachGatewayV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -88,7 +100,10 @@ func resourceNetworkingRoutingtableAttachGatewayV2Read(_ context.Context, d *sch
 }
 
 func resourceNetworkingRoutingtableAttachGatewayV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
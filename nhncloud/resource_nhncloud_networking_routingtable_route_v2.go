@@ -0,0 +1,138 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetworkingRoutingtableRouteV2() *schema.Resource {
+	s := routingtableRouteSchema(true)
+
+	s["region"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+
+	s["project_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	s["routingtable_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		ForceNew: true,
+	}
+
+	s["mask"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+
+	s["tenant_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceNetworkingRoutingtableRouteV2Create,
+		ReadContext:   resourceNetworkingRoutingtableRouteV2Read,
+		DeleteContext: resourceNetworkingRoutingtableRouteV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: s,
+	}
+}
+
+func resourceNetworkingRoutingtableRouteV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	routingtableID := d.Get("routingtable_id").(string)
+	createOpts := expandRoutingtableRouteCreateOpts(
+		routingtableID,
+		d.Get("cidr").(string),
+		d.Get("gateway_type").(string),
+		d.Get("gateway_target").(string),
+	)
+
+	// Routes of the same routing table cannot be changed concurrently.
+	config.MutexKV.Lock(routingtableID)
+	defer config.MutexKV.Unlock(routingtableID)
+
+	log.Printf("[DEBUG] nhncloud_networking_routingtable_route_v2 create options: %#v", createOpts)
+	route, err := routingtableRouteCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_routingtable_route_v2: %s", err)
+	}
+
+	d.SetId(route.ID)
+
+	log.Printf("[DEBUG] Created nhncloud_networking_routingtable_route_v2 %s: %#v", route.ID, route)
+	return resourceNetworkingRoutingtableRouteV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingRoutingtableRouteV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	route, err := routingtableRouteGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_routingtable_route_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_routingtable_route_v2 %s: %#v", d.Id(), route)
+
+	gatewayType, gatewayTarget := flattenRoutingtableRouteGateway(*route)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("routingtable_id", route.RoutingtableID)
+	d.Set("cidr", route.CIDR)
+	d.Set("gateway_type", gatewayType)
+	d.Set("gateway_target", gatewayTarget)
+	d.Set("mask", route.Mask)
+	d.Set("tenant_id", route.TenantID)
+
+	return nil
+}
+
+func resourceNetworkingRoutingtableRouteV2Delete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	routingtableID := d.Get("routingtable_id").(string)
+	config.MutexKV.Lock(routingtableID)
+	defer config.MutexKV.Unlock(routingtableID)
+
+	if err := routingtableRouteDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_routingtable_route_v2"))
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package nhncloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitNetworkingV2RoutingtableRoute_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	routingtableID := server.List("routingtables")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_routingtable_route_v2", "routes"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2RoutingtableRouteConfig(routingtableID, "ip", "192.168.0.10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "cidr", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "gateway_type", "ip"),
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "gateway_target", "192.168.0.10"),
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "mask", "16"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_routingtable_route_v2.route_1", "routes", "gateway", "192.168.0.10"),
+				),
+			},
+			{
+				ResourceName:      "nhncloud_networking_routingtable_route_v2.route_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// A route changed outside of Terraform is replaced.
+				PreConfig: func() {
+					route := server.List("routes")[0]
+					server.Update("routes", route["id"].(string), map[string]interface{}{"gateway": "192.168.0.20"})
+				},
+				Config:             providerConfig + testUnitNetworkingV2RoutingtableRouteConfig(routingtableID, "ip", "192.168.0.10"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2RoutingtableRouteConfig(routingtableID, "gateway", "gateway-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "gateway_type", "gateway"),
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "gateway_target", "gateway-1"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_routingtable_route_v2.route_1", "routes", "gateway_id", "gateway-1"),
+					testUnitCheckMockCloudRouteCount(server, routingtableID, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2RoutingtableRoute_projectID(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	routingtableID := server.List("routingtables")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_routingtable_route_v2", "routes"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+resource "nhncloud_networking_routingtable_route_v2" "route_1" {
+  project_id      = "other-project"
+  routingtable_id = "%s"
+  cidr            = "10.10.0.0/16"
+  gateway_type    = "ip"
+  gateway_target  = "192.168.0.10"
+}
+`, routingtableID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_route_v2.route_1", "tenant_id", "other-project"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_routingtable_route_v2.route_1", "routes", "tenant_id", "other-project"),
+				),
+			},
+		},
+	})
+}
+
+// testUnitCheckMockCloudRouteCount verifies the number of routes of a
+// routing table in the fake NHN Cloud.
+func testUnitCheckMockCloudRouteCount(server *mockcloud.Server, routingtableID string, count int) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		var n int
+		for _, route := range server.List("routes") {
+			if route["routingtable_id"] == routingtableID {
+				n++
+			}
+		}
+
+		if n != count {
+			return fmt.Errorf("Expected %d routes in routingtable %s, got %d", count, routingtableID, n)
+		}
+
+		return nil
+	}
+}
+
+func testUnitNetworkingV2RoutingtableRouteConfig(routingtableID, gatewayType, gatewayTarget string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_routingtable_route_v2" "route_1" {
+  routingtable_id = "%s"
+  cidr            = "10.10.0.0/16"
+  gateway_type    = "%s"
+  gateway_target  = "%s"
+}
+`, routingtableID, gatewayType, gatewayTarget)
+}
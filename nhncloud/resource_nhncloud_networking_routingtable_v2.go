@@ -28,6 +28,12 @@ func resourceNetworkingRoutingtableV2() *schema.Resource {
 		},
 
 		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -44,12 +50,26 @@ func resourceNetworkingRoutingtableV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+
+			// Only the routes of the inline blocks are managed, see
+			// filterRoutingtableRoutes.
+			"route": {
+				Type:       schema.TypeSet,
+				Optional:   true,
+				ConfigMode: schema.SchemaConfigModeAttr,
+				Elem: &schema.Resource{
+					Schema: routingtableRouteSchema(false),
+				},
+			},
 		},
 	}
 }
 
 func resourceNetworkingRoutingtableV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -80,12 +100,26 @@ func resourceNetworkingRoutingtableV2Create(ctx context.Context, d *schema.Resou
 
 	d.SetId(n.ID)
 
+	if v, ok := d.GetOk("route"); ok {
+		config.MutexKV.Lock(n.ID)
+		defer config.MutexKV.Unlock(n.ID)
+
+		routes := v.(*schema.Set)
+		err = updateRoutingtableRoutes(networkingClient, n.ID, schema.NewSet(routes.F, nil), routes)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	log.Printf("[DEBUG] Created nhncloud_networking_routingtable_v2 %s: %#v", n.ID, n)
 	return resourceNetworkingRoutingtableV2Read(ctx, d, meta)
 }
 
 func resourceNetworkingRoutingtableV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -108,11 +142,27 @@ func resourceNetworkingRoutingtableV2Read(ctx context.Context, d *schema.Resourc
 	d.Set("state", routingtable.State)
 	d.Set("create_time", routingtable.CreateTime)
 
+	routes, err := routingtableRouteList(networkingClient, d.Id())
+	if err != nil {
+		return diag.Errorf("Error listing routes of nhncloud_networking_routingtable_v2 %s: %s", d.Id(), err)
+	}
+	// nhncloud_networking_routingtable_attach_gateway_v2 reads through here
+	// too, without a route attribute.
+	if managed, ok := d.Get("route").(*schema.Set); ok {
+		routes = filterRoutingtableRoutes(routes, managed)
+		if err := d.Set("route", flattenRoutingtableRoutes(routes)); err != nil {
+			log.Printf("[DEBUG] Unable to set nhncloud_networking_routingtable_v2 %s route: %s", d.Id(), err)
+		}
+	}
+
 	return nil
 }
 
 func resourceNetworkingRoutingtableV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -137,17 +187,33 @@ func resourceNetworkingRoutingtableV2Update(ctx context.Context, d *schema.Resou
 	// Save basic updateOpts into finalUpdateOpts.
 	finalUpdateOpts = updateOpts
 
-	log.Printf("[DEBUG] nhncloud_networking_routingtable_v2 %s update options: %#v", d.Id(), finalUpdateOpts)
-	_, err = routingtables.Update(networkingClient, d.Id(), finalUpdateOpts).Extract()
-	if err != nil {
-		return diag.Errorf("Error updating nhncloud_networking_routingtable_v2 %s: %s", d.Id(), err)
+	if d.HasChanges("name", "distributed") {
+		log.Printf("[DEBUG] nhncloud_networking_routingtable_v2 %s update options: %#v", d.Id(), finalUpdateOpts)
+		_, err = routingtables.Update(networkingClient, d.Id(), finalUpdateOpts).Extract()
+		if err != nil {
+			return diag.Errorf("Error updating nhncloud_networking_routingtable_v2 %s: %s", d.Id(), err)
+		}
+	}
+
+	if d.HasChange("route") {
+		config.MutexKV.Lock(d.Id())
+		defer config.MutexKV.Unlock(d.Id())
+
+		oldRoutes, newRoutes := d.GetChange("route")
+		err = updateRoutingtableRoutes(networkingClient, d.Id(), oldRoutes.(*schema.Set), newRoutes.(*schema.Set))
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceNetworkingRoutingtableV2Read(ctx, d, meta)
 }
 
 func resourceNetworkingRoutingtableV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -0,0 +1,113 @@
+package nhncloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
+)
+
+func TestUnitNetworkingV2Routingtable_routes(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	vpcID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_routingtable_v2", "routingtables"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2RoutingtableConfigRoutes(vpcID, `
+  route {
+    cidr           = "10.10.0.0/16"
+    gateway_type   = "ip"
+    gateway_target = "192.168.0.10"
+  }
+
+  route {
+    cidr           = "10.20.0.0/16"
+    gateway_type   = "gateway"
+    gateway_target = "gateway-1"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_v2.routingtable_1", "route.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("nhncloud_networking_routingtable_v2.routingtable_1", "route.*", map[string]string{
+						"cidr":           "10.20.0.0/16",
+						"gateway_type":   "gateway",
+						"gateway_target": "gateway-1",
+					}),
+				),
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2RoutingtableConfigRoutes(vpcID, `
+  route {
+    cidr           = "10.10.0.0/16"
+    gateway_type   = "ip"
+    gateway_target = "192.168.0.11"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_v2.routingtable_1", "route.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("nhncloud_networking_routingtable_v2.routingtable_1", "route.*", map[string]string{
+						"cidr":           "10.10.0.0/16",
+						"gateway_target": "192.168.0.11",
+					}),
+				),
+			},
+			{
+				// A route added outside of Terraform, such as a system route,
+				// is not managed by the inline blocks.
+				PreConfig: func() {
+					server.Put("routes", map[string]interface{}{
+						"routingtable_id": testUnitNetworkingV2RoutingtableID(server, "routingtable_1"),
+						"cidr":            "10.30.0.0/16",
+						"mask":            16,
+						"gateway":         "192.168.0.12",
+					})
+				},
+				Config: providerConfig + testUnitNetworkingV2RoutingtableConfigRoutes(vpcID, `
+  route {
+    cidr           = "10.10.0.0/16"
+    gateway_type   = "ip"
+    gateway_target = "192.168.0.11"
+  }
+`),
+				PlanOnly: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2RoutingtableConfigRoutes(vpcID, `
+  route = []
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_routingtable_v2.routingtable_1", "route.#", "0"),
+					func(_ *terraform.State) error {
+						return testUnitCheckMockCloudRouteCount(server, testUnitNetworkingV2RoutingtableID(server, "routingtable_1"), 1)(nil)
+					},
+				),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2RoutingtableID(server *mockcloud.Server, name string) string {
+	for _, rt := range server.List("routingtables") {
+		if rt["name"] == name {
+			return rt["id"].(string)
+		}
+	}
+
+	return ""
+}
+
+func testUnitNetworkingV2RoutingtableConfigRoutes(vpcID, routes string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_routingtable_v2" "routingtable_1" {
+  name   = "routingtable_1"
+  vpc_id = "%s"
+%s
+}
+`, vpcID, routes)
+}
@@ -0,0 +1,241 @@
+package nhncloud
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetworkingServiceGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetworkingServiceGatewayV2Create,
+		ReadContext:   resourceNetworkingServiceGatewayV2Read,
+		UpdateContext: resourceNetworkingServiceGatewayV2Update,
+		DeleteContext: resourceNetworkingServiceGatewayV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"vpc_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subnet_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"service_endpoint_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"ip_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsIPv4Address,
+			},
+
+			"port_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkingServiceGatewayV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	createOpts := serviceGatewayCreateOpts{
+		Name:              d.Get("name").(string),
+		Description:       d.Get("description").(string),
+		VPCID:             d.Get("vpc_id").(string),
+		SubnetID:          d.Get("subnet_id").(string),
+		ServiceEndpointID: d.Get("service_endpoint_id").(string),
+		IPAddress:         d.Get("ip_address").(string),
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_service_gateway_v2 create options: %#v", createOpts)
+	gateway, err := serviceGatewayCreate(networkingClient, createOpts)
+	if err != nil {
+		return diag.Errorf("Error creating nhncloud_networking_service_gateway_v2: %s", err)
+	}
+
+	d.SetId(gateway.ID)
+
+	log.Printf("[DEBUG] Waiting for nhncloud_networking_service_gateway_v2 %s to become active.", gateway.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"", "BUILD", "PENDING_CREATE"},
+		Target:     []string{"ACTIVE"},
+		Refresh:    networkingServiceGatewayV2StateRefreshFunc(networkingClient, gateway.ID),
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_service_gateway_v2 %s to become active: %s", gateway.ID, err)
+	}
+
+	log.Printf("[DEBUG] Created nhncloud_networking_service_gateway_v2 %s: %#v", gateway.ID, gateway)
+	return resourceNetworkingServiceGatewayV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingServiceGatewayV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	gateway, err := serviceGatewayGet(networkingClient, d.Id())
+	if err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error getting nhncloud_networking_service_gateway_v2"))
+	}
+
+	log.Printf("[DEBUG] Retrieved nhncloud_networking_service_gateway_v2 %s: %#v", d.Id(), gateway)
+
+	d.Set("region", GetRegion(d, config))
+	d.Set("name", gateway.Name)
+	d.Set("description", gateway.Description)
+	d.Set("vpc_id", gateway.VPCID)
+	d.Set("subnet_id", gateway.SubnetID)
+	d.Set("service_endpoint_id", gateway.ServiceEndpointID)
+	d.Set("ip_address", gateway.IPAddress)
+	d.Set("port_id", gateway.PortID)
+	d.Set("state", gateway.State)
+	d.Set("tenant_id", gateway.TenantID)
+	d.Set("create_time", gateway.CreateTime)
+
+	return nil
+}
+
+func resourceNetworkingServiceGatewayV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	var updateOpts serviceGatewayUpdateOpts
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts.Name = &name
+	}
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+
+	log.Printf("[DEBUG] nhncloud_networking_service_gateway_v2 %s update options: %#v", d.Id(), updateOpts)
+	if _, err := serviceGatewayUpdate(networkingClient, d.Id(), updateOpts); err != nil {
+		return diag.Errorf("Error updating nhncloud_networking_service_gateway_v2 %s: %s", d.Id(), err)
+	}
+
+	return resourceNetworkingServiceGatewayV2Read(ctx, d, meta)
+}
+
+func resourceNetworkingServiceGatewayV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
+	if err != nil {
+		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
+	}
+
+	if err := serviceGatewayDelete(networkingClient, d.Id()); err != nil {
+		return diag.FromErr(CheckDeleted(d, err, "Error deleting nhncloud_networking_service_gateway_v2"))
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE", "DOWN", "ERROR", "PENDING_DELETE"},
+		Target:     []string{"DELETED"},
+		Refresh:    networkingServiceGatewayV2StateRefreshFunc(networkingClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      5 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return diag.Errorf("Error waiting for nhncloud_networking_service_gateway_v2 %s to delete: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -23,6 +23,8 @@ func resourceNetworkingVPCV2() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: networkingVPCV2CIDRCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -36,6 +38,12 @@ func resourceNetworkingVPCV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -68,7 +76,10 @@ func resourceNetworkingVPCV2() *schema.Resource {
 }
 
 func resourceNetworkingVPCV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -118,7 +129,10 @@ func resourceNetworkingVPCV2Create(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetworkingVPCV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -142,7 +156,10 @@ func resourceNetworkingVPCV2Read(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceNetworkingVPCV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -177,7 +194,10 @@ func resourceNetworkingVPCV2Update(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetworkingVPCV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -0,0 +1,145 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2VPC_basic(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpc_v2", "vpcs"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfig("vpc_1", "10.0.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "name", "vpc_1"),
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "cidrv4", "10.0.0.0/16"),
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "region", "KR1"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_vpc_v2.vpc_1", "vpcs", "state", "available"),
+				),
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfig("vpc_1_updated", "10.0.0.0/16"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "name", "vpc_1_updated"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_vpc_v2.vpc_1", "vpcs", "name", "vpc_1_updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2VPC_projectID(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpc_v2", "vpcs"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfigProjectID("other-project"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "project_id", "other-project"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_vpc_v2.vpc_1", "vpcs", "tenant_id", "other-project"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2VPC_cidrChange(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpc_v2", "vpcs"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfigSubnet("10.0.0.0/16"),
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfigSubnet("10.0.0.0/8"),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckMockCloudAttr(server, "nhncloud_networking_vpc_v2.vpc_1", "vpcs", "cidrv4", "10.0.0.0/8"),
+				),
+			},
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCConfigSubnet("10.1.0.0/16"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`does not contain subnet subnet_1 \(.+\) with cidr 10\.0\.1\.0/24`),
+			},
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCConfigSubnet("10.0.0.0/25"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`prefix length between /8 and /24`),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2VPC_unchangedCIDROutsideRules(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpc_v2", "vpcs"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCConfig("vpc_1", "10.0.0.0/16"),
+			},
+			{
+				// A VPC whose CIDR no longer passes the plan-time rules
+				// still plans while its CIDR is unchanged.
+				PreConfig: func() {
+					for _, vpc := range server.List("vpcs") {
+						server.Update("vpcs", vpc["id"].(string), map[string]interface{}{"cidrv4": "10.0.0.0/26"})
+					}
+				},
+				Config: providerConfig + testUnitNetworkingV2VPCConfig("vpc_1", "10.0.0.0/26"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_vpc_v2.vpc_1", "cidrv4", "10.0.0.0/26"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2VPCConfig(name, cidr string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "%s"
+  cidrv4 = "%s"
+}
+`, name, cidr)
+}
+
+func testUnitNetworkingV2VPCConfigProjectID(projectID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name       = "vpc_1"
+  cidrv4     = "10.0.0.0/16"
+  project_id = "%s"
+}
+`, projectID)
+}
+
+func testUnitNetworkingV2VPCConfigSubnet(cidr string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "%s"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_1" {
+  name   = "subnet_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.0.1.0/24"
+}
+`, cidr)
+}
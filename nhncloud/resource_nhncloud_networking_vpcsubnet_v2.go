@@ -25,6 +25,8 @@ func resourceNetworkingVPCSubnetV2() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		CustomizeDiff: networkingVPCSubnetV2CIDRCustomizeDiff,
+
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(10 * time.Minute),
@@ -38,12 +40,19 @@ func resourceNetworkingVPCSubnetV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"vpc_id": {
 				Type:     schema.TypeString,
 				Required: true,
 				ForceNew: true,
 			},
 
+			// The API can only rename a subnet, so a new cidr replaces it.
 			"cidr": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -184,7 +193,10 @@ func resourceNetworkingVPCSubnetV2() *schema.Resource {
 }
 
 func resourceNetworkingVPCSubnetV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -206,6 +218,11 @@ func resourceNetworkingVPCSubnetV2Create(ctx context.Context, d *schema.Resource
 		if netAddr.String() != cidr {
 			return diag.Errorf("cidr %s doesn't match subnet address %s for nhncloud_networking_vpcsubnet_v2", cidr, netAddr.String())
 		}
+		// The VPC may have been unknown when planning, check cidr against it
+		// now so that a conflict names the other subnet.
+		if err := vpcSubnetV2CheckCIDR(networkingClient, createOpts.VpcId, "", cidr, netAddr); err != nil {
+			return diag.FromErr(err)
+		}
 		createOpts.CIDR = cidr
 	}
 
@@ -248,7 +265,10 @@ func resourceNetworkingVPCSubnetV2Create(ctx context.Context, d *schema.Resource
 }
 
 func resourceNetworkingVPCSubnetV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -271,7 +291,10 @@ func resourceNetworkingVPCSubnetV2Read(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceNetworkingVPCSubnetV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
@@ -310,7 +333,10 @@ func resourceNetworkingVPCSubnetV2Update(ctx context.Context, d *schema.Resource
 }
 
 func resourceNetworkingVPCSubnetV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating NHN Cloud networking client: %s", err)
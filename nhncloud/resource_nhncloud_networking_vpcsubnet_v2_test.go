@@ -0,0 +1,85 @@
+package nhncloud
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestUnitNetworkingV2VPCSubnet_cidrValidation(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+	vpcID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpcsubnet_v2", "vpcsubnets"),
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCSubnetConfig(vpcID, "10.0.0.0/24"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is outside cidrv4 192\.168\.0\.0/16`),
+			},
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCSubnetConfig(vpcID, "192.168.0.128/25"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`overlaps subnet Default Network`),
+			},
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCSubnetConfig(vpcID, "192.168.1.0/29"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`prefix length between /8 and /28`),
+			},
+			{
+				Config:      providerConfig + testUnitNetworkingV2VPCSubnetConfig(vpcID, "192.168.1.1/24"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`doesn't match its network address 192\.168\.1\.0/24`),
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2VPCSubnetConfig(vpcID, "192.168.1.0/24"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_vpcsubnet_v2.subnet_1", "cidr", "192.168.1.0/24"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2VPCSubnet_cidrValidationNewVPC(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_vpcsubnet_v2", "vpcsubnets"),
+		Steps: []resource.TestStep{
+			{
+				// The VPC is unknown when planning, the subnet is checked
+				// before it is created.
+				Config: providerConfig + `
+resource "nhncloud_networking_vpc_v2" "vpc_1" {
+  name   = "vpc_1"
+  cidrv4 = "10.0.0.0/16"
+}
+
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_1" {
+  name   = "subnet_1"
+  vpc_id = nhncloud_networking_vpc_v2.vpc_1.id
+  cidr   = "10.1.0.0/24"
+}
+`,
+				ExpectError: regexp.MustCompile(`is outside cidrv4 10\.0\.0\.0/16`),
+			},
+		},
+	})
+}
+
+func testUnitNetworkingV2VPCSubnetConfig(vpcID, cidr string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_vpcsubnet_v2" "subnet_1" {
+  name   = "subnet_1"
+  vpc_id = "%s"
+  cidr   = "%s"
+}
+`, vpcID, cidr)
+}
@@ -175,6 +175,20 @@ func resourceBlockStorageVolumeV3() *schema.Resource {
 				},
 				Set: blockStorageExtensionsSchedulerHintsHash,
 			},
+
+			"nhn_encryption": {
+				Type:             schema.TypeList,
+				Optional:         true,
+				ForceNew:         true,
+				MaxItems:         1,
+				Elem:             blockStorageVolumeV3NhnEncryptionElem(),
+				DiffSuppressFunc: blockStorageVolumeV3NhnEncryptionDiffSuppress,
+			},
+
+			"encrypted": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -211,8 +225,11 @@ func resourceBlockStorageVolumeV3Create(ctx context.Context, d *schema.ResourceD
 		schedulerHints = resourceBlockStorageSchedulerHints(schedulerHintsRaw[0].(map[string]interface{}))
 	}
 	createOpts = schedulerhints.CreateOptsExt{
-		VolumeCreateOptsBuilder: volumeCreateOpts,
-		SchedulerHints:          schedulerHints,
+		VolumeCreateOptsBuilder: blockStorageVolumeV3CreateOptsExt{
+			CreateOptsBuilder: volumeCreateOpts,
+			NhnEncryption:     expandBlockStorageNhnEncryption(d.Get("nhn_encryption").([]interface{})),
+		},
+		SchedulerHints: schedulerHints,
 	}
 
 	log.Printf("[DEBUG] openstack_blockstorage_volume_v3 create options: %#v", createOpts)
@@ -264,6 +281,7 @@ func resourceBlockStorageVolumeV3Read(_ context.Context, d *schema.ResourceData,
 	d.Set("source_vol_id", v.SourceVolID)
 	d.Set("volume_type", v.VolumeType)
 	d.Set("metadata", v.Metadata)
+	d.Set("encrypted", v.Encrypted)
 	d.Set("region", GetRegion(d, config))
 
 	attachments := flattenBlockStorageVolumeV3Attachments(v.Attachments)
@@ -2,6 +2,7 @@ package nhncloud
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -320,3 +321,87 @@ resource "openstack_blockstorage_volume_v3" "volume_1" {
   }
 }
 `
+
+func TestUnitBlockStorageV3Volume_nhnEncryption(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_blockstorage_volume_v3", "volumes"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitBlockStorageV3VolumeNhnEncryption,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_volume_v3.volume_1", "encrypted", "true"),
+					resource.TestCheckResourceAttr("nhncloud_blockstorage_volume_v3.volume_2", "encrypted", "false"),
+					resource.TestCheckResourceAttr("data.nhncloud_blockstorage_volume_v3.volume_1", "encrypted", "true"),
+					resource.TestCheckResourceAttr("data.nhncloud_blockstorage_volume_v2.volume_2", "encrypted", "false"),
+				),
+			},
+			{
+				ResourceName:       "nhncloud_blockstorage_volume_v3.volume_1",
+				ImportState:        true,
+				ImportStateVerify:  true,
+				ImportStatePersist: true,
+				// The SKM key is not returned by the API.
+				ImportStateVerifyIgnore: []string{"nhn_encryption"},
+			},
+			{
+				// The imported volume is not replaced for its missing
+				// nhn_encryption.
+				Config:   providerConfig + testUnitBlockStorageV3VolumeNhnEncryption,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestUnitBlockStorageV3Volume_nhnEncryptionBlankKey(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+
+  nhn_encryption {
+    skm_appkey = "appkey"
+    skm_key_id = ""
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("skm_key_id"),
+			},
+		},
+	})
+}
+
+const testUnitBlockStorageV3VolumeNhnEncryption = `
+resource "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = "volume_1"
+  size = 10
+
+  nhn_encryption {
+    skm_appkey = "appkey"
+    skm_key_id = "key-1"
+  }
+}
+
+resource "nhncloud_blockstorage_volume_v3" "volume_2" {
+  name = "volume_2"
+  size = 10
+}
+
+data "nhncloud_blockstorage_volume_v3" "volume_1" {
+  name = nhncloud_blockstorage_volume_v3.volume_1.name
+}
+
+data "nhncloud_blockstorage_volume_v2" "volume_2" {
+  name = nhncloud_blockstorage_volume_v3.volume_2.name
+}
+`
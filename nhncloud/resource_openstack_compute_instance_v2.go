@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha1"
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -60,6 +62,12 @@ func resourceComputeInstanceV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -96,7 +104,8 @@ func resourceComputeInstanceV2() *schema.Resource {
 			"user_data": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+				// Replaces the instance unless rebuild_on_user_data_change is set,
+				// see resourceComputeInstanceV2RebuildCustomizeDiff.
 				// just stash the hash for state & diff comparisons
 				StateFunc: func(v interface{}) string {
 					switch v := v.(type) {
@@ -293,18 +302,7 @@ func resourceComputeInstanceV2() *schema.Resource {
 							Type:     schema.TypeList,
 							Optional: true,
 							ForceNew: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"skm_appkey": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"skm_key_id": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
+							Elem:     blockStorageNhnEncryptionElem(),
 						},
 					},
 				},
@@ -436,6 +434,21 @@ func resourceComputeInstanceV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"wait_for_log_pattern": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"rebuild_on_user_data_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			"vendor_options": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -474,12 +487,19 @@ func resourceComputeInstanceV2() *schema.Resource {
 			customdiff.ForceNewIfChange("flavor_name", func(ctx context.Context, old, new, meta interface{}) bool {
 				return old.(string) == ""
 			}),
+			resourceComputeInstanceV2RebuildCustomizeDiff,
+			objectTagsCustomizeDiff,
 		),
 	}
 }
 
 func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	start := time.Now()
+
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack compute client: %s", err)
@@ -537,7 +557,7 @@ func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData
 	configDrive := d.Get("config_drive").(bool)
 
 	// Retrieve tags and set microversion if they're provided.
-	instanceTags := computeV2InstanceTags(d)
+	instanceTags := computeV2InstanceTags(d, config.DefaultTags)
 	if len(instanceTags) > 0 {
 		computeClient.Microversion = computeV2InstanceCreateServerWithTagsMicroversion
 	}
@@ -649,6 +669,13 @@ func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData
 			server.ID, err)
 	}
 
+	if pattern := d.Get("wait_for_log_pattern").(string); pattern != "" {
+		timeout := d.Timeout(schema.TimeoutCreate) - time.Since(start)
+		if err := computeInstanceV2WaitForLogPattern(ctx, computeClient, d.Id(), pattern, timeout); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	vmState := d.Get("power_state").(string)
 	if strings.ToLower(vmState) == "shutoff" {
 		err = startstop.Stop(computeClient, d.Id()).ExtractErr()
@@ -675,7 +702,10 @@ func resourceComputeInstanceV2Create(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceComputeInstanceV2Read(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack compute client: %s", err)
@@ -807,7 +837,10 @@ func resourceComputeInstanceV2Read(_ context.Context, d *schema.ResourceData, me
 }
 
 func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack compute client: %s", err)
@@ -825,6 +858,16 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
+	// A stopped instance is resized before it is started, and a running one
+	// after it is stopped, so that it is never booted only to be resized.
+	flavorChanged := d.HasChange("flavor_id") || d.HasChange("flavor_name")
+	resizeBeforePowerState := flavorChanged && resourceComputeInstanceV2ResizeBeforePowerState(d)
+	if resizeBeforePowerState {
+		if err := resourceComputeInstanceV2Resize(ctx, computeClient, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if d.HasChange("power_state") {
 		powerStateOldRaw, powerStateNewRaw := d.GetChange("power_state")
 		powerStateOld := powerStateOldRaw.(string)
@@ -980,93 +1023,13 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	if d.HasChange("flavor_id") || d.HasChange("flavor_name") {
-		// Get vendor_options
-		vendorOptionsRaw := d.Get("vendor_options").(*schema.Set)
-		var ignoreResizeConfirmation bool
-		if vendorOptionsRaw.Len() > 0 {
-			vendorOptions := expandVendorOptions(vendorOptionsRaw.List())
-			ignoreResizeConfirmation = vendorOptions["ignore_resize_confirmation"].(bool)
-		}
-
-		var newFlavorID string
-		var err error
-		if d.HasChange("flavor_id") {
-			newFlavorID = d.Get("flavor_id").(string)
-		} else {
-			newFlavorName := d.Get("flavor_name").(string)
-			newFlavorID, err = flavorsutils.IDFromName(computeClient, newFlavorName)
-			if err != nil {
-				return diag.FromErr(err)
-			}
-		}
-
-		resizeOpts := &servers.ResizeOpts{
-			FlavorRef: newFlavorID,
-		}
-		log.Printf("[DEBUG] Resize configuration: %#v", resizeOpts)
-		err = servers.Resize(computeClient, d.Id(), resizeOpts).ExtractErr()
-		if err != nil {
-			return diag.Errorf("Error resizing OpenStack server: %s", err)
-		}
-
-		// Wait for the instance to finish resizing.
-		log.Printf("[DEBUG] Waiting for instance (%s) to finish resizing", d.Id())
-
-		// Resize instance without confirmation if specified by user.
-		if ignoreResizeConfirmation {
-			stateConf := &resource.StateChangeConf{
-				Pending:    []string{"RESIZE", "VERIFY_RESIZE"},
-				Target:     []string{"ACTIVE", "SHUTOFF"},
-				Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
-			}
-		} else {
-			stateConf := &resource.StateChangeConf{
-				Pending:    []string{"RESIZE"},
-				Target:     []string{"VERIFY_RESIZE"},
-				Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
-			}
-
-			// Confirm resize.
-			log.Printf("[DEBUG] Confirming resize")
-			err = servers.ConfirmResize(computeClient, d.Id()).ExtractErr()
-			if err != nil {
-				return diag.Errorf("Error confirming resize of OpenStack server: %s", err)
-			}
-
-			stateConf = &resource.StateChangeConf{
-				Pending:    []string{"VERIFY_RESIZE"},
-				Target:     []string{"ACTIVE", "SHUTOFF"},
-				Refresh:    ServerV2StateRefreshFunc(computeClient, d.Id()),
-				Timeout:    d.Timeout(schema.TimeoutUpdate),
-				Delay:      10 * time.Second,
-				MinTimeout: 3 * time.Second,
-			}
-
-			_, err = stateConf.WaitForStateContext(ctx)
-			if err != nil {
-				return diag.Errorf("Error waiting for instance (%s) to confirm resize: %s", d.Id(), err)
-			}
+	if flavorChanged && !resizeBeforePowerState {
+		if err := resourceComputeInstanceV2Resize(ctx, computeClient, d); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	if d.HasChange("image_id") || d.HasChange("image_name") || d.HasChange("personality") {
+	if d.HasChanges("image_id", "image_name", "user_data", "personality") {
 		var newImageID string
 		imageClient, err := config.ImageV2Client(GetRegion(d, config))
 		if err != nil {
@@ -1090,9 +1053,20 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 
 		var rebuildOpts servers.RebuildOptsBuilder = &servers.RebuildOpts{
 			ImageRef:    newImageID,
+			AdminPass:   d.Get("admin_pass").(string),
 			Personality: resourceInstancePersonalityV2(d),
 		}
 
+		// Only rebuild_on_user_data_change lets user_data change in place, the
+		// instance is replaced otherwise.
+		if d.HasChange("user_data") {
+			rebuildOpts = &computeInstanceV2RebuildOpts{
+				RebuildOpts: *rebuildOpts.(*servers.RebuildOpts),
+				UserData:    []byte(d.Get("user_data").(string)),
+			}
+			computeClient.Microversion = computeV2InstanceRebuildUserDataMicroversion
+		}
+
 		log.Printf("[DEBUG] Rebuild configuration: %#v", rebuildOpts)
 		_, err = servers.Rebuild(computeClient, d.Id(), rebuildOpts).Extract()
 		if err != nil {
@@ -1113,8 +1087,8 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 	}
 
 	// Perform any required updates to the tags.
-	if d.HasChange("tags") {
-		instanceTags := computeV2InstanceUpdateTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		instanceTags := computeV2InstanceUpdateTags(d, config.DefaultTags)
 		instanceTagsOpts := tags.ReplaceAllOpts{Tags: instanceTags}
 		computeClient.Microversion = computeV2TagsExtensionMicroversion
 		instanceTags, err := tags.ReplaceAll(computeClient, d.Id(), instanceTagsOpts).Extract()
@@ -1128,7 +1102,10 @@ func resourceComputeInstanceV2Update(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceComputeInstanceV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack compute client: %s", err)
@@ -1223,7 +1200,10 @@ func resourceOpenStackComputeInstanceV2ImportState(ctx context.Context, d *schem
 		VolumesAttached []map[string]interface{} `json:"os-extended-volumes:volumes_attached"`
 	}
 
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return nil, err
+	}
 	computeClient, err := config.ComputeV2Client(GetRegion(d, config))
 	if err != nil {
 		return nil, fmt.Errorf("Error creating OpenStack compute client: %s", err)
@@ -1549,6 +1529,286 @@ func setImageInformation(computeClient *gophercloud.ServiceClient, server *serve
 	return nil
 }
 
+// computeInstanceV2ConsoleOutputTailLines is the number of console output
+// lines reported when wait_for_log_pattern does not match.
+const computeInstanceV2ConsoleOutputTailLines = 30
+
+// computeInstanceV2WaitForLogPattern waits until the console output of the
+// instance matches pattern, and fails with the tail of the output if it does
+// not match before the timeout.
+func computeInstanceV2WaitForLogPattern(ctx context.Context, computeClient *gophercloud.ServiceClient, instanceID, pattern string, timeout time.Duration) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("Error parsing wait_for_log_pattern: %s", err)
+	}
+
+	var output string
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"WAITING"},
+		Target:  []string{"MATCHED"},
+		Refresh: func() (interface{}, string, error) {
+			var err error
+			output, err = servers.ShowConsoleOutput(computeClient, instanceID, servers.ShowConsoleOutputOpts{}).Extract()
+			if err != nil {
+				return nil, "", err
+			}
+
+			if re.MatchString(output) {
+				return output, "MATCHED", nil
+			}
+
+			return output, "WAITING", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for the console output of instance (%s) to match %q", instanceID, pattern)
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		if len(lines) > computeInstanceV2ConsoleOutputTailLines {
+			lines = lines[len(lines)-computeInstanceV2ConsoleOutputTailLines:]
+		}
+
+		return fmt.Errorf("Error waiting for the console output of instance (%s) to match %q: %s\n\n"+
+			"Last lines of the console output:\n%s", instanceID, pattern, err, strings.Join(lines, "\n"))
+	}
+
+	return nil
+}
+
+// resourceComputeInstanceV2RebuildCustomizeDiff replaces the instance when its
+// user_data changes, unless rebuild_on_user_data_change passes it to the in-place
+// rebuild so that its ports, floating IPs and volumes are kept. Image changes
+// are always rebuilt in place.
+func resourceComputeInstanceV2RebuildCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || diff.Get("rebuild_on_user_data_change").(bool) || !diff.HasChange("user_data") {
+		return nil
+	}
+
+	return diff.ForceNew("user_data")
+}
+
+// computeInstanceV2RebuildOpts adds the user_data of microversion 2.57 to
+// servers.RebuildOpts.
+type computeInstanceV2RebuildOpts struct {
+	servers.RebuildOpts
+	UserData []byte
+}
+
+func (opts computeInstanceV2RebuildOpts) ToServerRebuildMap() (map[string]interface{}, error) {
+	b, err := opts.RebuildOpts.ToServerRebuildMap()
+	if err != nil {
+		return nil, err
+	}
+
+	// Like servers.CreateOpts, user_data that is not base64 yet is encoded.
+	userData := string(opts.UserData)
+	if _, err := base64.StdEncoding.DecodeString(userData); err != nil {
+		userData = base64.StdEncoding.EncodeToString(opts.UserData)
+	}
+	b["rebuild"].(map[string]interface{})["user_data"] = userData
+
+	return b, nil
+}
+
+// resourceComputeInstanceV2ResizeBeforePowerState reports whether a flavor
+// change is applied before the power_state change of the same update.
+func resourceComputeInstanceV2ResizeBeforePowerState(d *schema.ResourceData) bool {
+	powerStateOldRaw, powerStateNewRaw := d.GetChange("power_state")
+	powerStateOld := strings.ToLower(powerStateOldRaw.(string))
+	powerStateNew := strings.ToLower(powerStateNewRaw.(string))
+
+	return powerStateNew != "shutoff" && powerStateOld != "shelved" && powerStateOld != "shelved_offloaded"
+}
+
+// resourceComputeInstanceV2Resize resizes the instance to its new flavor and
+// keeps its power state. The resize is confirmed once the instance reaches
+// VERIFY_RESIZE, unless ignore_resize_confirmation leaves that to the cloud,
+// and reverted if the instance is not on the new flavor or the confirmation
+// fails.
+func resourceComputeInstanceV2Resize(ctx context.Context, computeClient *gophercloud.ServiceClient, d *schema.ResourceData) error {
+	vendorOptionsRaw := d.Get("vendor_options").(*schema.Set)
+	var ignoreResizeConfirmation bool
+	if vendorOptionsRaw.Len() > 0 {
+		vendorOptions := expandVendorOptions(vendorOptionsRaw.List())
+		ignoreResizeConfirmation = vendorOptions["ignore_resize_confirmation"].(bool)
+	}
+
+	var newFlavorID string
+	var err error
+	if d.HasChange("flavor_id") {
+		newFlavorID = d.Get("flavor_id").(string)
+	} else {
+		newFlavorName := d.Get("flavor_name").(string)
+		newFlavorID, err = flavorsutils.IDFromName(computeClient, newFlavorName)
+		if err != nil {
+			return err
+		}
+	}
+
+	server, err := servers.Get(computeClient, d.Id()).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving OpenStack server (%s): %s", d.Id(), err)
+	}
+
+	// The instance comes back in the status it was resized in.
+	status := server.Status
+	if status != "ACTIVE" && status != "SHUTOFF" {
+		return fmt.Errorf("Error resizing instance (%s): it must be active or shutoff, not %s", d.Id(), strings.ToLower(status))
+	}
+
+	if err := checkComputeInstanceV2ResizeFlavor(computeClient, server, newFlavorID); err != nil {
+		return err
+	}
+
+	resizeOpts := &servers.ResizeOpts{
+		FlavorRef: newFlavorID,
+	}
+	log.Printf("[DEBUG] Resize configuration: %#v", resizeOpts)
+	err = servers.Resize(computeClient, d.Id(), resizeOpts).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error resizing OpenStack server: %s", err)
+	}
+
+	// Wait for the instance to finish resizing.
+	log.Printf("[DEBUG] Waiting for instance (%s) to finish resizing", d.Id())
+
+	// Resize instance without confirmation if specified by user.
+	if ignoreResizeConfirmation {
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"RESIZE", "VERIFY_RESIZE"},
+			Target:     []string{status},
+			Refresh:    computeInstanceV2ResizeStateRefreshFunc(computeClient, d.Id()),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		_, err = stateConf.WaitForStateContext(ctx)
+		if err != nil {
+			return fmt.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
+		}
+
+		return nil
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"RESIZE"},
+		Target:     []string{"VERIFY_RESIZE"},
+		Refresh:    computeInstanceV2ResizeStateRefreshFunc(computeClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	resized, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to resize: %s", d.Id(), err)
+	}
+
+	if flavorID, ok := resized.(*servers.Server).Flavor["id"].(string); ok && flavorID != newFlavorID {
+		return revertComputeInstanceV2Resize(ctx, computeClient, d, status,
+			fmt.Errorf("the instance is on flavor %s instead of %s", flavorID, newFlavorID))
+	}
+
+	// Confirm resize.
+	log.Printf("[DEBUG] Confirming resize")
+	err = servers.ConfirmResize(computeClient, d.Id()).ExtractErr()
+	if err != nil {
+		return revertComputeInstanceV2Resize(ctx, computeClient, d, status,
+			fmt.Errorf("Error confirming resize of OpenStack server: %s", err))
+	}
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"VERIFY_RESIZE"},
+		Target:     []string{status},
+		Refresh:    computeInstanceV2ResizeStateRefreshFunc(computeClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error waiting for instance (%s) to confirm resize: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// checkComputeInstanceV2ResizeFlavor fails early on a resize that NHN Cloud
+// rejects. An instance cannot move between flavors with a local root disk and
+// flavors that boot from block storage.
+func checkComputeInstanceV2ResizeFlavor(computeClient *gophercloud.ServiceClient, server *servers.Server, newFlavorID string) error {
+	flavorID, _ := server.Flavor["id"].(string)
+	flavor, err := flavors.Get(computeClient, flavorID).Extract()
+	if err != nil {
+		// The original flavor may have been deleted, leave it to the cloud.
+		log.Printf("[DEBUG] Unable to get flavor %s of instance (%s): %s", flavorID, server.ID, err)
+		return nil
+	}
+
+	newFlavor, err := flavors.Get(computeClient, newFlavorID).Extract()
+	if err != nil {
+		return fmt.Errorf("Error retrieving flavor %s: %s", newFlavorID, err)
+	}
+
+	if (flavor.Disk > 0) != (newFlavor.Disk > 0) {
+		return fmt.Errorf("Error resizing instance (%s) from flavor %s to %s: "+
+			"instances cannot be resized between flavors with a local root disk and flavors that boot from block storage",
+			server.ID, flavor.Name, newFlavor.Name)
+	}
+
+	return nil
+}
+
+// revertComputeInstanceV2Resize reverts a resize waiting for confirmation
+// and returns the reason of the revert.
+func revertComputeInstanceV2Resize(ctx context.Context, computeClient *gophercloud.ServiceClient, d *schema.ResourceData, status string, reason error) error {
+	log.Printf("[DEBUG] Reverting resize of instance (%s): %s", d.Id(), reason)
+	err := servers.RevertResize(computeClient, d.Id()).ExtractErr()
+	if err != nil {
+		return fmt.Errorf("Error resizing instance (%s): %s. Reverting the resize failed as well, "+
+			"the instance is left in VERIFY_RESIZE: %s", d.Id(), reason, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"REVERT_RESIZE", "VERIFY_RESIZE"},
+		Target:     []string{status},
+		Refresh:    computeInstanceV2ResizeStateRefreshFunc(computeClient, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		Delay:      10 * time.Second,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Error resizing instance (%s): %s. Error waiting for the resize to be reverted: %s", d.Id(), reason, err)
+	}
+
+	return fmt.Errorf("Error resizing instance (%s), it was reverted to its previous flavor: %s", d.Id(), reason)
+}
+
+// computeInstanceV2ResizeStateRefreshFunc is ServerV2StateRefreshFunc that
+// fails as soon as the instance goes into ERROR instead of timing out.
+func computeInstanceV2ResizeStateRefreshFunc(client *gophercloud.ServiceClient, instanceID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		s, err := servers.Get(client, instanceID).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if s.Status == "ERROR" {
+			return s, s.Status, fmt.Errorf("the instance went into ERROR: %s", s.Fault.Message)
+		}
+
+		return s, s.Status, nil
+	}
+}
+
 func getFlavorID(computeClient *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
 	if flavorID := d.Get("flavor_id").(string); flavorID != "" {
 		return flavorID, nil
@@ -1,8 +1,10 @@
 package nhncloud
 
 import (
+	"encoding/base64"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"testing"
@@ -17,6 +19,8 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/pagination"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
 )
 
 func TestAccComputeV2Instance_basic(t *testing.T) {
@@ -1778,3 +1782,441 @@ resource "openstack_compute_instance_v2" "instance_1" {
 }
 `, osNetworkID)
 }
+
+func TestUnitComputeV2Instance_resize(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID, resizeFlavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		} else {
+			resizeFlavorID = flavor["id"].(string)
+		}
+	}
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfig(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "name", "instance_1"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "flavor_id", flavorID),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "image_name", mockcloud.DefaultImageName),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "network.0.name", mockcloud.DefaultNetworkName),
+					resource.TestCheckResourceAttrSet("nhncloud_compute_instance_v2.instance_1", "access_ip_v4"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfig(imageID, resizeFlavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "flavor_id", resizeFlavorID),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitComputeV2Instance_resizeShutoff(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID, resizeFlavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		} else {
+			resizeFlavorID = flavor["id"].(string)
+		}
+	}
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfigPowerState(imageID, flavorID, networkID, "shutoff"),
+				Check: resource.ComposeTestCheckFunc(
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "SHUTOFF"),
+				),
+			},
+			{
+				// The instance is resized while it is stopped.
+				Config: providerConfig + testUnitComputeV2InstanceConfigPowerState(imageID, resizeFlavorID, networkID, "shutoff"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "flavor_id", resizeFlavorID),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "power_state", "shutoff"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "SHUTOFF"),
+				),
+			},
+			{
+				// The instance is resized before it is started.
+				Config: providerConfig + testUnitComputeV2InstanceConfigPowerState(imageID, flavorID, networkID, "active"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "flavor_id", flavorID),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "power_state", "active"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitComputeV2Instance_resizeRevert(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID, resizeFlavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		} else {
+			resizeFlavorID = flavor["id"].(string)
+		}
+	}
+	localDiskFlavorID := server.Put("flavors", map[string]interface{}{
+		"name":  "u2.c2m4",
+		"vcpus": 2,
+		"ram":   4096,
+		"disk":  20,
+	})
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfig(imageID, flavorID, networkID),
+			},
+			{
+				PreConfig: func() {
+					server.Update("servers", server.List("servers")[0]["id"].(string), map[string]interface{}{
+						"_confirm_resize_error": "compute host is unavailable",
+					})
+				},
+				Config:      providerConfig + testUnitComputeV2InstanceConfig(imageID, resizeFlavorID, networkID),
+				ExpectError: regexp.MustCompile("reverted to its previous flavor.*compute host is unavailable"),
+			},
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfig(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "flavor_id", flavorID),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+			{
+				Config:      providerConfig + testUnitComputeV2InstanceConfig(imageID, localDiskFlavorID, networkID),
+				ExpectError: regexp.MustCompile("local root disk"),
+			},
+		},
+	})
+}
+
+func TestUnitComputeV2Instance_rebuildOnUserDataChange(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+	imageID := server.List("images")[0]["id"].(string)
+	newImageID := server.Put("images", map[string]interface{}{
+		"name":       "Ubuntu Server 22.04 LTS",
+		"status":     "active",
+		"visibility": "public",
+		"min_disk":   20,
+	})
+	networkID := server.List("vpcs")[0]["id"].(string)
+
+	var instanceID, portID string
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfigRebuild(imageID, flavorID, networkID, "v1", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "image_id", imageID),
+					testUnitComputeV2InstanceStoreIDs(&instanceID, &portID),
+				),
+			},
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfigRebuild(newImageID, flavorID, networkID, "v2", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "image_id", newImageID),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "image_name", "Ubuntu Server 22.04 LTS"),
+					// The instance and its port are kept.
+					func(s *terraform.State) error {
+						var newInstanceID, newPortID string
+						if err := testUnitComputeV2InstanceStoreIDs(&newInstanceID, &newPortID)(s); err != nil {
+							return err
+						}
+						if newInstanceID != instanceID || newPortID != portID {
+							return fmt.Errorf("Expected instance %s with port %s to be rebuilt, got instance %s with port %s",
+								instanceID, portID, newInstanceID, newPortID)
+						}
+						return nil
+					},
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers",
+						"OS-EXT-SRV-ATTR:user_data", base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho v2\n"))),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+			{
+				// Without rebuild_on_user_data_change an image change is still
+				// rebuilt in place.
+				Config: providerConfig + testUnitComputeV2InstanceConfigRebuild(imageID, flavorID, networkID, "v2", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "image_id", imageID),
+					func(s *terraform.State) error {
+						var newInstanceID, newPortID string
+						if err := testUnitComputeV2InstanceStoreIDs(&newInstanceID, &newPortID)(s); err != nil {
+							return err
+						}
+						if newInstanceID != instanceID || newPortID != portID {
+							return fmt.Errorf("Expected instance %s with port %s to be rebuilt, got instance %s with port %s",
+								instanceID, portID, newInstanceID, newPortID)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Without rebuild_on_user_data_change a user_data change replaces
+				// the instance.
+				Config: providerConfig + testUnitComputeV2InstanceConfigRebuild(imageID, flavorID, networkID, "v3", false),
+				Check: resource.ComposeTestCheckFunc(
+					func(s *terraform.State) error {
+						var newInstanceID, newPortID string
+						if err := testUnitComputeV2InstanceStoreIDs(&newInstanceID, &newPortID)(s); err != nil {
+							return err
+						}
+						if newInstanceID == instanceID {
+							return fmt.Errorf("Expected instance %s to be replaced", instanceID)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testUnitComputeV2InstanceStoreIDs(instanceID, portID *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["nhncloud_compute_instance_v2.instance_1"]
+		if !ok {
+			return fmt.Errorf("Not found: nhncloud_compute_instance_v2.instance_1")
+		}
+
+		*instanceID = rs.Primary.ID
+		*portID = rs.Primary.Attributes["network.0.port"]
+
+		return nil
+	}
+}
+
+func testUnitComputeV2InstanceConfigRebuild(imageID, flavorID, networkID, version string, rebuild bool) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name                    = "instance_1"
+  image_id                = "%s"
+  flavor_id               = "%s"
+  admin_pass              = "Secret-1234"
+  user_data               = "#!/bin/sh\necho %s\n"
+  rebuild_on_user_data_change = %t
+  network {
+    uuid = "%s"
+  }
+}
+`, imageID, flavorID, version, rebuild, networkID)
+}
+
+func testUnitComputeV2InstanceConfig(imageID, flavorID, networkID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, imageID, flavorID, networkID)
+}
+
+func testUnitComputeV2InstanceConfigPowerState(imageID, flavorID, networkID, powerState string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name        = "instance_1"
+  image_id    = "%s"
+  flavor_id   = "%s"
+  power_state = "%s"
+  network {
+    uuid = "%s"
+  }
+}
+`, imageID, flavorID, powerState, networkID)
+}
+
+func TestUnitComputeV2Instance_defaultTags(t *testing.T) {
+	server, providers, _ := testUnitMockCloud(t)
+
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: testUnitMockCloudProviderConfig(server, `
+  default_tags {
+    tags = ["team=platform"]
+  }
+`) + testUnitComputeV2InstanceConfigTags(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "tags.*", "app"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.*", "team=platform"),
+				),
+			},
+			{
+				Config: testUnitMockCloudProviderConfig(server, `
+  default_tags {
+    tags = ["team=platform", "env=prod"]
+  }
+`) + testUnitComputeV2InstanceConfigTags(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "tags.#", "1"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.#", "3"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.*", "env=prod"),
+				),
+			},
+			{
+				// A tag removed from the provider default_tags is removed
+				// from the instance, a tag set through the API is kept.
+				PreConfig: func() {
+					instance := server.List("servers")[0]
+					tags, _ := instance["tags"].([]interface{})
+					server.Update("servers", instance["id"].(string), map[string]interface{}{
+						"tags": append(tags, "external"),
+					})
+				},
+				Config: testUnitMockCloudProviderConfig(server, `
+  default_tags {
+    tags = ["env=prod"]
+  }
+`) + testUnitComputeV2InstanceConfigTags(imageID, flavorID, networkID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "tags.#", "1"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.#", "3"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.*", "app"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.*", "env=prod"),
+					resource.TestCheckTypeSetElemAttr("nhncloud_compute_instance_v2.instance_1", "all_tags.*", "external"),
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "default_tags.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testUnitComputeV2InstanceConfigTags(imageID, flavorID, networkID string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name      = "instance_1"
+  image_id  = "%s"
+  flavor_id = "%s"
+  network {
+    uuid = "%s"
+  }
+  tags = ["app"]
+}
+`, imageID, flavorID, networkID)
+}
+
+func TestUnitComputeV2Instance_waitForLogPattern(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitComputeV2InstanceConfigWaitForLogPattern(imageID, flavorID, networkID, "instance_1 login:"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_compute_instance_v2.instance_1", "wait_for_log_pattern", "instance_1 login:"),
+					testUnitCheckMockCloudAttr(server, "nhncloud_compute_instance_v2.instance_1", "servers", "status", "ACTIVE"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitComputeV2Instance_waitForLogPatternTimeout(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	imageID := server.List("images")[0]["id"].(string)
+	networkID := server.List("vpcs")[0]["id"].(string)
+	var flavorID string
+	for _, flavor := range server.List("flavors") {
+		if flavor["name"] == mockcloud.DefaultFlavorName {
+			flavorID = flavor["id"].(string)
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_compute_instance_v2", "servers"),
+		Steps: []resource.TestStep{
+			{
+				Config:      providerConfig + testUnitComputeV2InstanceConfigWaitForLogPattern(imageID, flavorID, networkID, "cloud-init .* finished"),
+				ExpectError: regexp.MustCompile(`(?s)Last lines of the console output:.*instance_1 login:`),
+			},
+		},
+	})
+}
+
+func testUnitComputeV2InstanceConfigWaitForLogPattern(imageID, flavorID, networkID, pattern string) string {
+	return fmt.Sprintf(`
+resource "nhncloud_compute_instance_v2" "instance_1" {
+  name                 = "instance_1"
+  image_id             = "%s"
+  flavor_id            = "%s"
+  wait_for_log_pattern = "%s"
+  network {
+    uuid = "%s"
+  }
+
+  timeouts {
+    create = "30s"
+  }
+}
+`, imageID, flavorID, pattern, networkID)
+}
@@ -125,6 +125,12 @@ func resourceNetworkingNetworkV2() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"availability_zone_hints": {
 				Type:     schema.TypeSet,
 				Computed: true,
@@ -166,6 +172,8 @@ func resourceNetworkingNetworkV2() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
@@ -287,7 +295,7 @@ func resourceNetworkingNetworkV2Create(ctx context.Context, d *schema.ResourceDa
 
 	d.SetId(n.ID)
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "networks", n.ID, tagOpts).Extract()
@@ -372,8 +380,8 @@ func resourceNetworkingNetworkV2Update(ctx context.Context, d *schema.ResourceDa
 	}
 
 	// Change tags if needed.
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "networks", d.Id(), tagOpts).Extract()
 		if err != nil {
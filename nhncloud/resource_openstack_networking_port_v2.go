@@ -44,6 +44,12 @@ func resourceNetworkingPortV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -209,6 +215,12 @@ func resourceNetworkingPortV2() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			"port_security_enabled": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -276,11 +288,16 @@ func resourceNetworkingPortV2() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
 func resourceNetworkingPortV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -413,7 +430,7 @@ func resourceNetworkingPortV2Create(ctx context.Context, d *schema.ResourceData,
 
 	d.SetId(port.ID)
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "ports", port.ID, tagOpts).Extract()
@@ -428,7 +445,10 @@ func resourceNetworkingPortV2Create(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetworkingPortV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -477,7 +497,10 @@ func resourceNetworkingPortV2Read(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNetworkingPortV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -657,8 +680,8 @@ func resourceNetworkingPortV2Update(ctx context.Context, d *schema.ResourceData,
 	}
 
 	// Next, perform any required updates to the tags.
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "ports", d.Id(), tagOpts).Extract()
 		if err != nil {
@@ -671,7 +694,10 @@ func resourceNetworkingPortV2Update(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetworkingPortV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
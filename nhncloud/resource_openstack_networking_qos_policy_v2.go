@@ -102,7 +102,15 @@ func resourceNetworkingQoSPolicyV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
@@ -147,7 +155,7 @@ func resourceNetworkingQoSPolicyV2Create(ctx context.Context, d *schema.Resource
 
 	d.SetId(p.ID)
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "qos/policies", p.ID, tagOpts).Extract()
@@ -237,8 +245,8 @@ func resourceNetworkingQoSPolicyV2Update(ctx context.Context, d *schema.Resource
 		}
 	}
 
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "qos/policies", d.Id(), tagOpts).Extract()
 		if err != nil {
@@ -173,7 +173,15 @@ func resourceNetworkingRouterV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
@@ -319,7 +327,7 @@ func resourceNetworkingRouterV2Create(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	tags := networkingV2AttributesTags(d)
+	tags := networkingV2AttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "routers", r.ID, tagOpts).Extract()
@@ -468,8 +476,8 @@ func resourceNetworkingRouterV2Update(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Next, perform any required updates to the tags.
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "routers", d.Id(), tagOpts).Extract()
 		if err != nil {
@@ -2,16 +2,18 @@ package nhncloud
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/attributestags"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
-	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 )
 
 func resourceNetworkingSecGroupV2() *schema.Resource {
@@ -36,6 +38,12 @@ func resourceNetworkingSecGroupV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -60,6 +68,70 @@ func resourceNetworkingSecGroupV2() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      networkingSecgroupV2RuleHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"direction": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"ingress", "egress"}, false),
+						},
+
+						"ethertype": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "IPv4",
+							ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+						},
+
+						"protocol": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"port_range_min": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+
+						"port_range_max": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+
+						"remote_ip_prefix": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"remote_group_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -71,12 +143,46 @@ func resourceNetworkingSecGroupV2() *schema.Resource {
 				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 		},
+
+		CustomizeDiff: customdiff.Sequence(
+			objectTagsCustomizeDiff,
+			networkingSecgroupV2RulesCustomizeDiff,
+		),
 	}
 }
 
+// networkingSecgroupV2RulesCustomizeDiff rejects inline rules on a secgroup
+// that is not authoritative, as they would never be applied. An authoritative
+// secgroup deletes every rule that is not declared, so it must also set
+// delete_default_rules rather than silently losing the default rules.
+func networkingSecgroupV2RulesCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Get("authoritative").(bool) {
+		if !diff.Get("delete_default_rules").(bool) {
+			return fmt.Errorf("authoritative openstack_networking_secgroup_v2 requires delete_default_rules to be true")
+		}
+
+		return nil
+	}
+
+	if diff.Get("rule").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("rule blocks of openstack_networking_secgroup_v2 require authoritative to be true")
+	}
+
+	return nil
+}
+
 func resourceNetworkingSecGroupV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -103,16 +209,24 @@ func resourceNetworkingSecGroupV2Create(ctx context.Context, d *schema.ResourceD
 			return diag.Errorf("Error retrieving the created openstack_networking_secgroup_v2 %s: %s", sgID, err)
 		}
 
-		for _, rule := range sg.Rules {
-			if err := rules.Delete(networkingClient, rule.ID).ExtractErr(); err != nil {
-				return diag.Errorf("Error deleting a default rule for openstack_networking_secgroup_v2 %s: %s", sgID, err)
-			}
+		if err := networkingSecgroupV2DeleteRules(networkingClient, sgID, sg.Rules); err != nil {
+			return diag.Errorf("Error deleting the default rules of openstack_networking_secgroup_v2 %s: %s", sgID, err)
 		}
 	}
 
 	d.SetId(sg.ID)
 
-	tags := networkingV2AttributesTags(d)
+	// An authoritative secgroup ends up with exactly the inline rules.
+	if d.Get("authoritative").(bool) {
+		config.MutexKV.Lock(sg.ID)
+		err := networkingSecgroupV2ReconcileRules(networkingClient, sg.ID, d.Get("rule").(*schema.Set))
+		config.MutexKV.Unlock(sg.ID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	tags := networkingV2AttributesTags(d, config.DefaultTags)
 	if len(tags) > 0 {
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "security-groups", sg.ID, tagOpts).Extract()
@@ -128,7 +242,10 @@ func resourceNetworkingSecGroupV2Create(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetworkingSecGroupV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -144,13 +261,24 @@ func resourceNetworkingSecGroupV2Read(ctx context.Context, d *schema.ResourceDat
 	d.Set("name", sg.Name)
 	d.Set("region", GetRegion(d, config))
 
+	// Every rule of an authoritative secgroup is read, so the rules that were
+	// not created from the configuration show up as drift.
+	if d.Get("authoritative").(bool) {
+		if err := d.Set("rule", networkingSecgroupV2FlattenRules(sg.Rules)); err != nil {
+			log.Printf("[DEBUG] Unable to set openstack_networking_secgroup_v2 %s rule: %s", d.Id(), err)
+		}
+	}
+
 	networkingV2ReadAttributesTags(d, sg.Tags)
 
 	return nil
 }
 
 func resourceNetworkingSecGroupV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
@@ -180,8 +308,17 @@ func resourceNetworkingSecGroupV2Update(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	if d.HasChange("tags") {
-		tags := networkingV2UpdateAttributesTags(d)
+	if d.Get("authoritative").(bool) && d.HasChanges("authoritative", "rule") {
+		config.MutexKV.Lock(d.Id())
+		err := networkingSecgroupV2ReconcileRules(networkingClient, d.Id(), d.Get("rule").(*schema.Set))
+		config.MutexKV.Unlock(d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("tags", "all_tags") {
+		tags := networkingV2UpdateAttributesTags(d, config.DefaultTags)
 		tagOpts := attributestags.ReplaceAllOpts{Tags: tags}
 		tags, err := attributestags.ReplaceAll(networkingClient, "security-groups", d.Id(), tagOpts).Extract()
 		if err != nil {
@@ -194,7 +331,10 @@ func resourceNetworkingSecGroupV2Update(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetworkingSecGroupV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	config := meta.(*Config)
+	config, err := GetProjectConfig(d, meta.(*Config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	networkingClient, err := config.NetworkingV2Client(GetRegion(d, config))
 	if err != nil {
 		return diag.Errorf("Error creating OpenStack networking client: %s", err)
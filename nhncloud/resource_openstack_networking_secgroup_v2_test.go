@@ -2,12 +2,15 @@ package nhncloud
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+
+	"github.com/nhn-cloud/terraform-provider-nhncloud/nhncloud/internal/mockcloud"
 )
 
 func TestAccNetworkingV2SecGroup_basic(t *testing.T) {
@@ -180,3 +183,149 @@ resource "openstack_networking_secgroup_v2" "secgroup_1" {
   }
 }
 `
+
+func TestUnitNetworkingV2SecGroup_authoritative(t *testing.T) {
+	server, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		CheckDestroy:      testUnitCheckMockCloudDestroyed(server, "nhncloud_networking_secgroup_v2", "security-groups"),
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testUnitNetworkingV2SecGroupAuthoritativeConfig(22),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_secgroup_v2.secgroup_1", "rule.#", "2"),
+					testUnitCheckNetworkingV2SecGroupRuleCount(server, "nhncloud_networking_secgroup_v2.secgroup_1", 2),
+				),
+			},
+			{
+				// A rule added out of band is reported as drift.
+				PreConfig: func() {
+					for _, sg := range server.List("security-groups") {
+						if sg["name"] == "secgroup_1" {
+							server.Put("security-group-rules", map[string]interface{}{
+								"security_group_id": sg["id"],
+								"direction":         "ingress",
+								"ethertype":         "IPv4",
+								"protocol":          "tcp",
+								"port_range_min":    3306,
+								"port_range_max":    3306,
+								"remote_ip_prefix":  "0.0.0.0/0",
+							})
+						}
+					}
+				},
+				Config:             providerConfig + testUnitNetworkingV2SecGroupAuthoritativeConfig(22),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2SecGroupAuthoritativeConfig(22),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("nhncloud_networking_secgroup_v2.secgroup_1", "rule.#", "2"),
+					testUnitCheckNetworkingV2SecGroupRuleCount(server, "nhncloud_networking_secgroup_v2.secgroup_1", 2),
+				),
+			},
+			{
+				Config: providerConfig + testUnitNetworkingV2SecGroupAuthoritativeConfig(2222),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("nhncloud_networking_secgroup_v2.secgroup_1", "rule.*", map[string]string{
+						"direction":      "ingress",
+						"port_range_min": "2222",
+					}),
+					testUnitCheckNetworkingV2SecGroupRuleCount(server, "nhncloud_networking_secgroup_v2.secgroup_1", 2),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2SecGroup_ruleNotAuthoritative(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_networking_secgroup_v2" "secgroup_1" {
+  name = "secgroup_1"
+
+  rule {
+    direction = "egress"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile("require authoritative"),
+			},
+		},
+	})
+}
+
+func TestUnitNetworkingV2SecGroup_authoritativeDefaultRules(t *testing.T) {
+	_, providers, providerConfig := testUnitMockCloud(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: providers,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "nhncloud_networking_secgroup_v2" "secgroup_1" {
+  name          = "secgroup_1"
+  authoritative = true
+
+  rule {
+    direction = "egress"
+  }
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("requires delete_default_rules"),
+			},
+		},
+	})
+}
+
+func testUnitCheckNetworkingV2SecGroupRuleCount(server *mockcloud.Server, n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		var rules int
+		for _, rule := range server.List("security-group-rules") {
+			if rule["security_group_id"] == rs.Primary.ID {
+				rules++
+			}
+		}
+
+		if rules != count {
+			return fmt.Errorf("Expected %d rules in security group %s, got %d", count, rs.Primary.ID, rules)
+		}
+
+		return nil
+	}
+}
+
+func testUnitNetworkingV2SecGroupAuthoritativeConfig(sshPort int) string {
+	return fmt.Sprintf(`
+resource "nhncloud_networking_secgroup_v2" "secgroup_1" {
+  name                 = "secgroup_1"
+  delete_default_rules = true
+  authoritative        = true
+
+  rule {
+    direction        = "ingress"
+    protocol         = "tcp"
+    port_range_min   = %[1]d
+    port_range_max   = %[1]d
+    remote_ip_prefix = "10.0.0.0/8"
+  }
+
+  rule {
+    direction = "egress"
+  }
+}
+`, sshPort)
+}
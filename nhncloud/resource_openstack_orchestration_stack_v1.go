@@ -75,6 +75,18 @@ func resourceOrchestrationStackV1() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 
+			"all_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"default_tags": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
 			// Below are schemas for stack read
 			"capabilities": {
 				Type:     schema.TypeList,
@@ -158,6 +170,8 @@ func resourceOrchestrationStackV1() *schema.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: objectTagsCustomizeDiff,
 	}
 }
 
@@ -190,14 +204,7 @@ func resourceOrchestrationStackV1Create(ctx context.Context, d *schema.ResourceD
 	if d.Get("parameters") != nil {
 		createOpts.Parameters = d.Get("parameters").(map[string]interface{})
 	}
-	if d.Get("tags") != nil {
-		t := d.Get("tags").([]interface{})
-		tags := make([]string, len(t))
-		for _, tag := range t {
-			tags = append(tags, tag.(string))
-		}
-		createOpts.Tags = tags
-	}
+	createOpts.Tags = orchestrationStackV1Tags(d, config.DefaultTags)
 	if d.Get("timeout") != nil {
 		createOpts.Timeout = d.Get("timeout").(int)
 	}
@@ -285,7 +292,8 @@ func resourceOrchestrationStackV1Read(ctx context.Context, d *schema.ResourceDat
 				tags = append(tags, v)
 			}
 		}
-		d.Set("tags", tags)
+		d.Set("all_tags", tags)
+		d.Set("tags", orchestrationStackV1ReadTags(d, tags, config.DefaultTags))
 	}
 
 	if err := d.Set("creation_time", stack.CreationTime.Format(time.RFC3339)); err != nil {
@@ -328,14 +336,7 @@ func resourceOrchestrationStackV1Update(ctx context.Context, d *schema.ResourceD
 	if d.Get("timeout") != nil {
 		updateOpts.Timeout = d.Get("timeout").(int)
 	}
-	if d.Get("tags") != nil {
-		t := d.Get("tags").([]interface{})
-		tags := make([]string, len(t))
-		for _, tag := range t {
-			tags = append(tags, tag.(string))
-		}
-		updateOpts.Tags = tags
-	}
+	updateOpts.Tags = orchestrationStackV1Tags(d, config.DefaultTags)
 
 	stack, err := stacks.Find(orchestrationClient, d.Id()).Extract()
 	if err != nil {
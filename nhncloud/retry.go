@@ -0,0 +1,217 @@
+package nhncloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// defaultRetryableStatusCodes are retried when the retry block does not list
+// any status codes.
+var defaultRetryableStatusCodes = []int{
+	http.StatusConflict,
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryPolicy is the provider-wide policy for retrying throttled and failed
+// API calls, configured by the provider retry block.
+type retryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	Jitter      bool
+	StatusCodes map[int]struct{}
+}
+
+// expandProviderRetryPolicy returns the policy of the provider retry block,
+// or nil if the block is not set.
+func expandProviderRetryPolicy(v []interface{}) (*retryPolicy, error) {
+	if len(v) == 0 || v[0] == nil {
+		return nil, nil
+	}
+
+	raw := v[0].(map[string]interface{})
+
+	baseBackoff, err := time.ParseDuration(raw["base_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing retry base_backoff: %s", err)
+	}
+
+	maxBackoff, err := time.ParseDuration(raw["max_backoff"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing retry max_backoff: %s", err)
+	}
+
+	if maxBackoff < baseBackoff {
+		return nil, fmt.Errorf("retry max_backoff %s must not be less than base_backoff %s", maxBackoff, baseBackoff)
+	}
+
+	statusCodes := raw["retryable_status_codes"].(*schema.Set).List()
+	policy := &retryPolicy{
+		MaxAttempts: raw["max_attempts"].(int),
+		BaseBackoff: baseBackoff,
+		MaxBackoff:  maxBackoff,
+		Jitter:      raw["jitter"].(bool),
+		StatusCodes: make(map[int]struct{}),
+	}
+
+	if len(statusCodes) == 0 {
+		for _, code := range defaultRetryableStatusCodes {
+			policy.StatusCodes[code] = struct{}{}
+		}
+	}
+	for _, code := range statusCodes {
+		policy.StatusCodes[code.(int)] = struct{}{}
+	}
+
+	return policy, nil
+}
+
+// backoff returns how long to wait before the given retry, starting at 1.
+// A Retry-After header of the response takes precedence over the policy,
+// but is capped at MaxBackoff too.
+func (p *retryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if wait > p.MaxBackoff {
+			wait = p.MaxBackoff
+		}
+		return wait
+	}
+
+	wait := p.BaseBackoff
+	for i := 1; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	// Keep at least half of the backoff, so the jitter does not turn it
+	// into a busy loop.
+	if p.Jitter && wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := time.Parse(http.TimeFormat, v); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// retryRoundTripper retries requests that got a retryable status code, so
+// every resource gets the same handling of throttling and server errors.
+type retryRoundTripper struct {
+	rt     http.RoundTripper
+	policy *retryPolicy
+
+	// sleep waits between attempts. It is replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryRoundTripper(rt http.RoundTripper, policy *retryPolicy) *retryRoundTripper {
+	return &retryRoundTripper{
+		rt:     rt,
+		policy: policy,
+		sleep:  sleepContext,
+	}
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A request body that cannot be replayed is sent only once.
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	attempt := req
+	for retry := 1; ; retry++ {
+		resp, err := rt.rt.RoundTrip(attempt)
+		if err != nil || !replayable || retry >= rt.policy.MaxAttempts {
+			return resp, err
+		}
+
+		if _, ok := rt.policy.StatusCodes[resp.StatusCode]; !ok {
+			return resp, nil
+		}
+
+		if !retryableNonIdempotent(req.Method, resp) {
+			return resp, nil
+		}
+
+		wait := rt.policy.backoff(retry, resp)
+		log.Printf("[DEBUG] NHN Cloud API %s %s returned %d, retry %d of %d in %s",
+			req.Method, req.URL, resp.StatusCode, retry, rt.policy.MaxAttempts-1, wait)
+
+		// Drain the body so that the connection can be reused.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if err := rt.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+
+		attempt = req.Clone(req.Context())
+		if req.GetBody != nil {
+			if attempt.Body, err = req.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryableNonIdempotent reports whether a response may be retried for the
+// request method. A POST or PATCH that failed with a server error may have
+// been applied anyway, and sending it again could create a duplicate, so it
+// is only retried when the API rejected it before processing: when it was
+// throttled, the service was unavailable or a Retry-After header was sent.
+func retryableNonIdempotent(method string, resp *http.Response) bool {
+	if method != http.MethodPost && method != http.MethodPatch {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	}
+
+	return resp.Header.Get("Retry-After") != ""
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
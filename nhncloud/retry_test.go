@@ -0,0 +1,203 @@
+package nhncloud
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testUnitRetryClient(policy *retryPolicy) (*http.Client, *[]time.Duration) {
+	var waits []time.Duration
+
+	rt := newRetryRoundTripper(http.DefaultTransport, policy)
+	rt.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	return &http.Client{Transport: rt}, &waits
+}
+
+func TestUnitRetryRoundTripper(t *testing.T) {
+	var bodies []string
+	codes := []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+
+		code := codes[len(bodies)-1]
+		if code == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "7")
+		}
+		w.WriteHeader(code)
+	}))
+	defer server.Close()
+
+	client, waits := testUnitRetryClient(&retryPolicy{
+		MaxAttempts: 5,
+		BaseBackoff: time.Second,
+		MaxBackoff:  10 * time.Second,
+		StatusCodes: map[int]struct{}{http.StatusTooManyRequests: {}, http.StatusServiceUnavailable: {}},
+	})
+
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"vpc": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{`{"vpc": {}}`, `{"vpc": {}}`, `{"vpc": {}}`}, bodies)
+	assert.Equal(t, []time.Duration{7 * time.Second, 2 * time.Second}, *waits)
+}
+
+func TestUnitRetryRoundTripper_exhausted(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer server.Close()
+
+	client, waits := testUnitRetryClient(&retryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Second,
+		StatusCodes: map[int]struct{}{http.StatusConflict: {}},
+	})
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{time.Second, time.Second}, *waits)
+}
+
+func TestUnitRetryRoundTripper_notRetryable(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, waits := testUnitRetryClient(&retryPolicy{
+		MaxAttempts: 3,
+		StatusCodes: map[int]struct{}{http.StatusConflict: {}},
+	})
+
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, *waits)
+}
+
+func TestUnitRetryRoundTripper_nonIdempotent(t *testing.T) {
+	var attempts int
+	var retryAfter string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if retryAfter != "" {
+			w.Header().Set("Retry-After", retryAfter)
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, waits := testUnitRetryClient(&retryPolicy{
+		MaxAttempts: 3,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Second,
+		StatusCodes: map[int]struct{}{http.StatusInternalServerError: {}},
+	})
+
+	// A create that failed with a server error may have been applied, so
+	// it is not sent again.
+	resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"server": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, *waits)
+
+	// Idempotent requests are still retried.
+	attempts = 0
+	resp, err = client.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+
+	// A Retry-After header tells that the request can be sent again.
+	attempts = 0
+	retryAfter = "1"
+	resp, err = client.Post(server.URL, "application/json", strings.NewReader(`{"server": {}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestUnitRetryPolicyBackoff(t *testing.T) {
+	policy := &retryPolicy{
+		BaseBackoff: time.Second,
+		MaxBackoff:  5 * time.Second,
+	}
+	resp := &http.Response{Header: http.Header{}}
+
+	assert.Equal(t, time.Second, policy.backoff(1, resp))
+	assert.Equal(t, 2*time.Second, policy.backoff(2, resp))
+	assert.Equal(t, 4*time.Second, policy.backoff(3, resp))
+	assert.Equal(t, 5*time.Second, policy.backoff(4, resp))
+
+	policy.Jitter = true
+	for i := 0; i < 10; i++ {
+		wait := policy.backoff(3, resp)
+		assert.GreaterOrEqual(t, wait, 2*time.Second)
+		assert.LessOrEqual(t, wait, 4*time.Second)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.Equal(t, time.Duration(0), policy.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, policy.backoff(1, resp))
+
+	// A Retry-After longer than max_backoff is capped.
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, 5*time.Second, policy.backoff(1, resp))
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.Equal(t, 5*time.Second, policy.backoff(1, resp))
+}
+
+func TestUnitExpandProviderRetryPolicy(t *testing.T) {
+	policy, err := expandProviderRetryPolicy(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	raw := map[string]interface{}{
+		"max_attempts":           4,
+		"base_backoff":           "500ms",
+		"max_backoff":            "20s",
+		"jitter":                 false,
+		"retryable_status_codes": schema.NewSet(schema.HashInt, nil),
+	}
+
+	policy, err = expandProviderRetryPolicy([]interface{}{raw})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, policy.MaxAttempts)
+	assert.Equal(t, 500*time.Millisecond, policy.BaseBackoff)
+	assert.Equal(t, 20*time.Second, policy.MaxBackoff)
+	assert.Len(t, policy.StatusCodes, len(defaultRetryableStatusCodes))
+
+	raw["retryable_status_codes"] = schema.NewSet(schema.HashInt, []interface{}{429})
+	policy, err = expandProviderRetryPolicy([]interface{}{raw})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]struct{}{http.StatusTooManyRequests: {}}, policy.StatusCodes)
+
+	raw["max_backoff"] = "100ms"
+	_, err = expandProviderRetryPolicy([]interface{}{raw})
+	assert.Error(t, err)
+}
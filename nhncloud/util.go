@@ -1,6 +1,7 @@
 package nhncloud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -50,6 +51,14 @@ func GetRegion(d *schema.ResourceData, config *Config) string {
 	return config.Region
 }
 
+// GetProjectConfig returns the provider configuration scoped to the
+// resource's project_id, or the provider configuration if it is not set.
+func GetProjectConfig(d *schema.ResourceData, config *Config) (*Config, error) {
+	projectID := d.Get("project_id").(string)
+
+	return config.ProjectConfig(projectID, GetRegion(d, config))
+}
+
 // AddValueSpecs expands the 'value_specs' object and removes 'value_specs'
 // from the reqeust body.
 func AddValueSpecs(body map[string]interface{}) map[string]interface{} {
@@ -137,17 +146,18 @@ func expandObjectReadTags(d *schema.ResourceData, tags []string) {
 	}
 }
 
-func expandObjectUpdateTags(d *schema.ResourceData) []string {
-	allTags := d.Get("all_tags").(*schema.Set)
-	oldTagsRaw, newTagsRaw := d.GetChange("tags")
-	oldTags, newTags := oldTagsRaw.(*schema.Set), newTagsRaw.(*schema.Set)
-
-	allTagsWithoutOld := allTags.Difference(oldTags)
+// expandObjectUpdateTags returns the tags to set on an existing resource, see
+// objectAllTags.
+func expandObjectUpdateTags(d *schema.ResourceData, defaultTags []string) []string {
+	oldAllTags, _ := d.GetChange("all_tags")
+	oldTags, tags := d.GetChange("tags")
+	oldDefaultTags, _ := d.GetChange("default_tags")
 
-	return expandToStringSlice(allTagsWithoutOld.Union(newTags).List())
+	return objectAllTags(expandObjectTagsList(oldAllTags), expandObjectTagsList(oldTags),
+		expandObjectTagsList(oldDefaultTags), expandObjectTagsList(tags), defaultTags)
 }
 
-func expandObjectTags(d *schema.ResourceData) []string {
+func expandObjectTags(d *schema.ResourceData, defaultTags []string) []string {
 	rawTags := d.Get("tags").(*schema.Set).List()
 	tags := make([]string, len(rawTags))
 
@@ -155,9 +165,97 @@ func expandObjectTags(d *schema.ResourceData) []string {
 		tags[i] = raw.(string)
 	}
 
+	return mergeDefaultTags(tags, defaultTags)
+}
+
+// mergeDefaultTags appends the provider default tags that are not already
+// present in tags.
+func mergeDefaultTags(tags []string, defaultTags []string) []string {
+	for _, tag := range defaultTags {
+		if !strSliceContains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
 	return tags
 }
 
+// objectAllTags returns the tags of a resource merged with the provider
+// default tags. The tags that were neither configured nor a default tag were
+// set outside of Terraform and are kept, while a tag removed from the tags or
+// from the provider default_tags is removed from the resource too.
+func objectAllTags(oldAllTags, oldTags, oldDefaultTags, tags, defaultTags []string) []string {
+	var allTags []string
+	for _, tag := range oldAllTags {
+		if !strSliceContains(oldTags, tag) && !strSliceContains(oldDefaultTags, tag) {
+			allTags = append(allTags, tag)
+		}
+	}
+
+	return sliceUnion(sliceUnion(allTags, tags), defaultTags)
+}
+
+// expandObjectTagsList returns the tags of a tags attribute, which is a set
+// for most resources and a list for stacks.
+func expandObjectTagsList(v interface{}) []string {
+	switch v := v.(type) {
+	case *schema.Set:
+		return expandToStringSlice(v.List())
+	case []interface{}:
+		return expandToStringSlice(v)
+	}
+
+	return nil
+}
+
+// objectTagsCustomizeDiff records the provider default tags applied to a
+// resource in default_tags and plans all_tags of an existing resource, so
+// that adding a default tag to or removing one from the provider reaches
+// resources whose own tags did not change.
+func objectTagsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+
+	defaultTags := schema.NewSet(schema.HashString, expandToInterfaceSlice(config.DefaultTags))
+	if !defaultTags.Equal(diff.Get("default_tags").(*schema.Set)) {
+		if err := diff.SetNew("default_tags", defaultTags.List()); err != nil {
+			return err
+		}
+	}
+
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if !diff.NewValueKnown("tags") {
+		return diff.SetNewComputed("all_tags")
+	}
+
+	oldAllTags, _ := diff.GetChange("all_tags")
+	oldTags, tags := diff.GetChange("tags")
+	oldDefaultTags, _ := diff.GetChange("default_tags")
+
+	allTags := objectAllTags(expandObjectTagsList(oldAllTags), expandObjectTagsList(oldTags),
+		expandObjectTagsList(oldDefaultTags), expandObjectTagsList(tags), config.DefaultTags)
+	planned := schema.NewSet(schema.HashString, expandToInterfaceSlice(allTags))
+	if planned.Equal(diff.Get("all_tags").(*schema.Set)) {
+		return nil
+	}
+
+	return diff.SetNew("all_tags", planned.List())
+}
+
+func expandToInterfaceSlice(v []string) []interface{} {
+	s := make([]interface{}, len(v))
+	for i, val := range v {
+		s[i] = val
+	}
+
+	return s
+}
+
 func expandToMapStringString(v map[string]interface{}) map[string]string {
 	m := make(map[string]string)
 	for key, val := range v {
@@ -81,3 +81,26 @@ func TestUnitMapDiffWithNilValues(t *testing.T) {
 	assert.Equal(t, result["c"], "3")
 	assert.Equal(t, len(result), 3)
 }
+
+func TestUnitMergeDefaultTags(t *testing.T) {
+	tags := []string{"app", "team=platform"}
+	defaultTags := []string{"team=platform", "env=prod"}
+
+	expected := []string{"app", "team=platform", "env=prod"}
+
+	assert.Equal(t, expected, mergeDefaultTags(tags, defaultTags))
+	assert.Equal(t, defaultTags, mergeDefaultTags(nil, defaultTags))
+	assert.Equal(t, tags, mergeDefaultTags(tags, nil))
+}
+
+func TestUnitObjectAllTags(t *testing.T) {
+	oldAllTags := []string{"app", "team=platform", "external"}
+	oldTags := []string{"app"}
+	oldDefaultTags := []string{"team=platform"}
+
+	// The tag set through the API is kept, the removed default tag is not.
+	expected := []string{"external", "app", "env=prod"}
+
+	assert.Equal(t, expected, objectAllTags(oldAllTags, oldTags, oldDefaultTags, []string{"app"}, []string{"env=prod"}))
+	assert.Equal(t, []string{"external"}, objectAllTags(oldAllTags, oldTags, oldDefaultTags, nil, nil))
+}